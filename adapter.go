@@ -2,6 +2,8 @@ package jsoniter
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"io"
 )
 
@@ -30,6 +32,17 @@ func Get(data []byte, path ...interface{}) Any {  // 从嵌套的json结构中
 	return ConfigDefault.Get(data, path...)
 }
 
+// GetPointer gets the value of data by the RFC 6901 JSON Pointer, such as /items/0/name.
+// The numeric token is the index of array, or the key of object.
+func GetPointer(data []byte, pointer string) Any {
+	return ConfigDefault.GetPointer(data, pointer)
+}
+
+// Query selects the nodes of data by the RFC 9535 JSONPath, such as $.store.book[?@.price < 10].title
+func Query(data []byte, path string) Any {
+	return ConfigDefault.Query(data, path)
+}
+
 // 输出结果格式： {"ID":1,"Name":"Reds","Colors":["Crimson","Red","Ruby","Maroon"]}
 // Marshal adapts to json/encoding Marshal API
 //
@@ -73,26 +86,62 @@ func NewDecoder(reader io.Reader) *Decoder {
 }
 
 // Decoder reads and decodes JSON values from an input stream.
-// Decoder provides identical APIs with json/stream Decoder (Token() and UseNumber() are in progress)
+// Decoder provides identical APIs with json/stream Decoder
 type Decoder struct {
-	iter *Iterator
+	iter       *Iterator
+	tokenState int
+	tokenStack []int
 }
 
 // Decode decode JSON into interface{}
 func (adapter *Decoder) Decode(obj interface{}) error {
 	if adapter.iter.head == adapter.iter.tail && adapter.iter.reader != nil {
 		if !adapter.iter.loadMore() {
+			if adapter.iter.Error != nil && adapter.iter.Error != io.EOF {
+				return adapter.iter.Error
+			}
 			return io.EOF
 		}
 	}
+	if !adapter.tokenPrepareForDecode() {
+		return adapter.iter.Error
+	}
+	adapter.iter.collectedErrors = nil
+	if adapter.iter.cfg.standardLibraryErrors {
+		return adapter.decodeWithStandardLibraryErrors(obj)
+	}
 	adapter.iter.ReadVal(obj)
 	err := adapter.iter.Error
+	if len(adapter.iter.collectedErrors) > 0 {
+		if err == nil || err == io.EOF {
+			adapter.tokenValueEnd()
+		}
+		return adapter.iter.collectedError()
+	}
 	if err == io.EOF {
+		adapter.tokenValueEnd()
 		return nil
 	}
+	if err == nil {
+		adapter.tokenValueEnd()
+	}
 	return adapter.iter.Error
 }
 
+// DecodeContext is Decode stopping once ctx is done, see Iterator.SetContext.
+// The error wraps ctx.Err(), with the position where decoding stopped.
+// The io.Reader is read by a goroutine during the call. If ctx is done while it is blocked in Read,
+// the goroutine is left behind until Read returns, and the Decoder should not be used after that.
+func (adapter *Decoder) DecodeContext(ctx context.Context, obj interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	previous := adapter.iter.ctx
+	adapter.iter.SetContext(ctx)
+	defer adapter.iter.SetContext(previous)
+	return adapter.Decode(obj)
+}
+
 // More is there more?
 func (adapter *Decoder) More() bool {
 	iter := adapter.iter
@@ -107,6 +156,228 @@ func (adapter *Decoder) More() bool {
 	return c != ']' && c != '}'
 }
 
+// decodeWithStandardLibraryErrors decodes the next value reporting the errors as json/stream Decoder,
+// *json.SyntaxError or *json.UnmarshalTypeError, and io.ErrUnexpectedEOF if the input ends in the value
+func (adapter *Decoder) decodeWithStandardLibraryErrors(obj interface{}) error {
+	if err := checkUnmarshalTarget(obj); err != nil {
+		return err
+	}
+	iter := adapter.iter
+	if iter.nextToken() == 0 {
+		return io.EOF
+	}
+	iter.unreadByte()
+	base := iter.consumed + int64(iter.head)
+	iter.ReadVal(obj)
+	if len(iter.collectedErrors) > 0 {
+		if iter.Error == nil || iter.Error == io.EOF {
+			adapter.tokenValueEnd()
+		}
+		return iter.collectedError()
+	}
+	if iter.Error == nil || iter.Error == io.EOF {
+		adapter.tokenValueEnd()
+		return nil
+	}
+	err := iter.standardLibraryError(base)
+	switch err.(type) {
+	case *json.UnmarshalTypeError:
+		// the value is skipped, the next one can be decoded, the same as json.Decoder
+		adapter.tokenValueEnd()
+	case *json.SyntaxError:
+		if iter.Error.(*DecodeError).endOfInput {
+			return io.ErrUnexpectedEOF
+		}
+	}
+	return err
+}
+
+// token states, the same as the ones used by json/stream Decoder
+const (
+	tokenTopValue = iota
+	tokenArrayStart
+	tokenArrayValue
+	tokenArrayComma
+	tokenObjectStart
+	tokenObjectKey
+	tokenObjectColon
+	tokenObjectValue
+	tokenObjectComma
+)
+
+// Token returns the next JSON token in the input stream.
+// At the end of the input stream, Token returns nil, io.EOF.
+//
+// Token guarantees that the delimiters [ ] { } it returns are properly nested and matched.
+// Commas and colons are elided. Values are returned as json.Delim, bool, float64
+// (or json.Number if UseNumber is set), string and nil, same as json/stream Decoder.
+// Refer to https://godoc.org/encoding/json#Decoder.Token for more information
+func (adapter *Decoder) Token() (json.Token, error) {
+	iter := adapter.iter
+	if iter.Error != nil && iter.Error != io.EOF {
+		return nil, iter.Error
+	}
+	for {
+		c := iter.nextToken()
+		switch c {
+		case 0:
+			if iter.Error == nil || iter.Error == io.EOF {
+				return nil, io.EOF
+			}
+			return nil, iter.Error
+		case '[':
+			if !adapter.tokenValueAllowed() {
+				return adapter.tokenError(c)
+			}
+			adapter.tokenStack = append(adapter.tokenStack, adapter.tokenState)
+			adapter.tokenState = tokenArrayStart
+			return json.Delim('['), nil
+		case ']':
+			if adapter.tokenState != tokenArrayStart && adapter.tokenState != tokenArrayComma {
+				return adapter.tokenError(c)
+			}
+			adapter.popTokenState()
+			return json.Delim(']'), nil
+		case '{':
+			if !adapter.tokenValueAllowed() {
+				return adapter.tokenError(c)
+			}
+			adapter.tokenStack = append(adapter.tokenStack, adapter.tokenState)
+			adapter.tokenState = tokenObjectStart
+			return json.Delim('{'), nil
+		case '}':
+			if adapter.tokenState != tokenObjectStart && adapter.tokenState != tokenObjectComma {
+				return adapter.tokenError(c)
+			}
+			adapter.popTokenState()
+			return json.Delim('}'), nil
+		case ':':
+			if adapter.tokenState != tokenObjectColon {
+				return adapter.tokenError(c)
+			}
+			adapter.tokenState = tokenObjectValue
+			continue
+		case ',':
+			if adapter.tokenState == tokenArrayComma {
+				adapter.tokenState = tokenArrayValue
+				continue
+			}
+			if adapter.tokenState == tokenObjectComma {
+				adapter.tokenState = tokenObjectKey
+				continue
+			}
+			return adapter.tokenError(c)
+		case '"':
+			if adapter.tokenState == tokenObjectStart || adapter.tokenState == tokenObjectKey {
+				iter.unreadByte()
+				key := iter.ReadString()
+				if iter.Error != nil && iter.Error != io.EOF {
+					return nil, iter.Error
+				}
+				adapter.tokenState = tokenObjectColon
+				return key, nil
+			}
+		}
+		if !adapter.tokenValueAllowed() {
+			return adapter.tokenError(c)
+		}
+		iter.unreadByte()
+		token := adapter.readTokenValue()
+		if iter.Error != nil && iter.Error != io.EOF {
+			return nil, iter.Error
+		}
+		adapter.tokenValueEnd()
+		return token, nil
+	}
+}
+
+func (adapter *Decoder) readTokenValue() json.Token {
+	iter := adapter.iter
+	switch iter.WhatIsNext() {
+	case StringValue:
+		return iter.ReadString()
+	case NumberValue:
+		if iter.cfg.configBeforeFrozen.UseNumber {
+			return json.Number(iter.readNumberAsString())
+		}
+		return iter.ReadFloat64()
+	case BoolValue:
+		return iter.ReadBool()
+	case NilValue:
+		iter.skipFourBytes('n', 'u', 'l', 'l')
+		return nil
+	default:
+		iter.ReportError("Token", "unexpected character "+string([]byte{iter.nextToken()}))
+		return nil
+	}
+}
+
+func (adapter *Decoder) popTokenState() {
+	last := len(adapter.tokenStack) - 1
+	adapter.tokenState = adapter.tokenStack[last]
+	adapter.tokenStack = adapter.tokenStack[:last]
+	adapter.tokenValueEnd()
+}
+
+func (adapter *Decoder) tokenValueAllowed() bool {
+	switch adapter.tokenState {
+	case tokenTopValue, tokenArrayStart, tokenArrayValue, tokenObjectValue:
+		return true
+	}
+	return false
+}
+
+func (adapter *Decoder) tokenValueEnd() {
+	switch adapter.tokenState {
+	case tokenArrayStart, tokenArrayValue:
+		adapter.tokenState = tokenArrayComma
+	case tokenObjectValue:
+		adapter.tokenState = tokenObjectComma
+	}
+}
+
+// tokenPrepareForDecode consumes the separator in front of the next value,
+// so that Decode can be mixed with Token
+func (adapter *Decoder) tokenPrepareForDecode() bool {
+	iter := adapter.iter
+	switch adapter.tokenState {
+	case tokenArrayComma:
+		c := iter.nextToken()
+		if c != ',' {
+			iter.ReportError("Decode", "expect , after array element, but found "+string([]byte{c}))
+			return false
+		}
+		adapter.tokenState = tokenArrayValue
+	case tokenObjectColon:
+		c := iter.nextToken()
+		if c != ':' {
+			iter.ReportError("Decode", "expect : after object key, but found "+string([]byte{c}))
+			return false
+		}
+		adapter.tokenState = tokenObjectValue
+	}
+	return true
+}
+
+func (adapter *Decoder) tokenError(c byte) (json.Token, error) {
+	var context string
+	switch adapter.tokenState {
+	case tokenTopValue, tokenArrayStart, tokenArrayValue, tokenObjectValue:
+		context = "looking for beginning of value"
+	case tokenArrayComma:
+		context = "after array element"
+	case tokenObjectKey:
+		context = "looking for beginning of object key string"
+	case tokenObjectColon:
+		context = "after object key"
+	case tokenObjectComma:
+		context = "after object key:value pair"
+	}
+	adapter.iter.unreadByte()
+	adapter.iter.ReportError("Token", "invalid character "+string([]byte{c})+" "+context)
+	return nil, adapter.iter.Error
+}
+
 // Buffered remaining buffer
 func (adapter *Decoder) Buffered() io.Reader {
 	remaining := adapter.iter.buf[adapter.iter.head:adapter.iter.tail]
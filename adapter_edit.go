@@ -0,0 +1,207 @@
+package jsoniter
+
+import (
+	"fmt"
+	"io"
+)
+
+// Set returns a copy of data with the value at path replaced by value, located as Get.
+// The missing key of the last object in path is added as the last member.
+// The rest of data is kept byte-identical, including key order and formatting.
+func Set(data []byte, value interface{}, path ...interface{}) ([]byte, error) {
+	return ConfigDefault.Set(data, value, path...)
+}
+
+// SetCreatingParents is Set creating the missing objects in path
+func SetCreatingParents(data []byte, value interface{}, path ...interface{}) ([]byte, error) {
+	return ConfigDefault.SetCreatingParents(data, value, path...)
+}
+
+// Delete returns a copy of data without the value at path, and the separator next to it
+func Delete(data []byte, path ...interface{}) ([]byte, error) {
+	return ConfigDefault.Delete(data, path...)
+}
+
+func (cfg *frozenConfig) Set(data []byte, value interface{}, path ...interface{}) ([]byte, error) {
+	return cfg.edit(data, path, &jsonEdit{value: value})
+}
+
+func (cfg *frozenConfig) SetCreatingParents(data []byte, value interface{}, path ...interface{}) ([]byte, error) {
+	return cfg.edit(data, path, &jsonEdit{value: value, creatingParents: true})
+}
+
+func (cfg *frozenConfig) Delete(data []byte, path ...interface{}) ([]byte, error) {
+	return cfg.edit(data, path, &jsonEdit{deleting: true})
+}
+
+type jsonEdit struct {
+	value           interface{}
+	creatingParents bool
+	deleting        bool
+}
+
+func (cfg *frozenConfig) edit(data []byte, path []interface{}, edit *jsonEdit) ([]byte, error) {
+	iter := cfg.BorrowIterator(data)
+	defer cfg.ReturnIterator(iter)
+	iter.WhatIsNext()
+	span := valueSpan{start: iter.head, prevEnd: -1}
+	if span.start >= len(data) {
+		return nil, fmt.Errorf("can not edit empty input")
+	}
+	// the whole document is validated, not only the values before the path
+	iter.Skip()
+	end := iter.head
+	if (iter.Error == nil || iter.Error == io.EOF) && iter.nextToken() != 0 {
+		iter.ReportError("edit", "there are bytes left after the value")
+	}
+	if iter.Error != nil && iter.Error != io.EOF {
+		return nil, iter.Error
+	}
+	iter.Error = nil
+	if len(path) == 0 {
+		if edit.deleting {
+			return nil, fmt.Errorf("can not delete the whole document")
+		}
+		return cfg.replaceSpan(data, span.start, end, edit, nil, "")
+	}
+	for i := 0; ; i++ {
+		container := span.start
+		iter.head = container
+		switch pathKey := path[i].(type) {
+		case string:
+			if data[container] != '{' {
+				return nil, fmt.Errorf("%v: %v is not object", path, path[:i])
+			}
+			if edit.deleting && i == len(path)-1 {
+				return deleteMembers(data, path, container, locateObjectFieldSpans(iter, pathKey), iter.Error)
+			}
+			span = locateObjectFieldSpan(iter, pathKey)
+		case int:
+			if data[container] != '[' {
+				return nil, fmt.Errorf("%v: %v is not array", path, path[:i])
+			}
+			span = locateArrayElementSpan(iter, pathKey)
+		default:
+			return nil, fmt.Errorf("%v: unsupported path %v", path, pathKey)
+		}
+		if iter.Error != nil && iter.Error != io.EOF {
+			return nil, iter.Error
+		}
+		if span.start == -1 {
+			return cfg.insertMember(data, path, i, iter.head-1, span.prevEnd, edit)
+		}
+		if i < len(path)-1 {
+			continue
+		}
+		if edit.deleting {
+			return deleteSpan(data, container, span), nil
+		}
+		return cfg.replaceSpan(data, span.start, span.end, edit, nil, "")
+	}
+}
+
+// insertMember adds the member of the missing key path[i] into the object ending at closing
+func (cfg *frozenConfig) insertMember(data []byte, path []interface{}, i int, closing int, lastEnd int, edit *jsonEdit) ([]byte, error) {
+	if edit.deleting || i < len(path)-1 && !edit.creatingParents {
+		return nil, fmt.Errorf("%v not found", path[:i+1])
+	}
+	var keys []string
+	for _, pathKey := range path[i:] {
+		key, isKey := pathKey.(string)
+		if !isKey {
+			return nil, fmt.Errorf("%v not found", path[:i+1])
+		}
+		keys = append(keys, key)
+	}
+	if lastEnd == -1 {
+		return cfg.replaceSpan(data, closing, closing, edit, keys, "")
+	}
+	return cfg.replaceSpan(data, lastEnd, lastEnd, edit, keys, ",")
+}
+
+// replaceSpan replaces data[start:end] with the encoded value,
+// the value is written as the member of keys, with the missing objects in between
+func (cfg *frozenConfig) replaceSpan(data []byte, start int, end int, edit *jsonEdit, keys []string, separator string) ([]byte, error) {
+	stream := cfg.BorrowStream(nil)
+	defer cfg.ReturnStream(stream)
+	stream.WriteRaw(separator)
+	for i, key := range keys {
+		if i > 0 {
+			stream.WriteObjectStart()
+		}
+		stream.WriteObjectField(key)
+	}
+	stream.WriteVal(edit.value)
+	for i := 1; i < len(keys); i++ {
+		stream.WriteObjectEnd()
+	}
+	if stream.Error != nil {
+		return nil, stream.Error
+	}
+	replacement := stream.Buffer()
+	edited := make([]byte, 0, len(data)-(end-start)+len(replacement))
+	edited = append(edited, data[:start]...)
+	edited = append(edited, replacement...)
+	return append(edited, data[end:]...), nil
+}
+
+// locateObjectFieldSpans locates every member of target, so that Delete removes the duplicate keys as well,
+// which would be found by Get otherwise
+func locateObjectFieldSpans(iter *Iterator, target string) []valueSpan {
+	var spans []valueSpan
+	lastEnd := -1
+	iter.ReadObjectCB(func(iter *Iterator, field string) bool {
+		switch {
+		case field != target:
+			iter.Skip()
+		case len(spans) > 0 && iter.cfg.duplicateKeys == DuplicateKeysError:
+			iter.ReportError("locateObjectField", duplicateKeyMessage(target))
+			return false
+		default:
+			spans = append(spans, iter.skipSpan(lastEnd))
+		}
+		lastEnd = iter.head
+		return true
+	})
+	return spans
+}
+
+// deleteMembers removes the members located in the object starting at container, from the last one
+func deleteMembers(data []byte, path []interface{}, container int, spans []valueSpan, err error) ([]byte, error) {
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if len(spans) == 0 {
+		return nil, fmt.Errorf("%v not found", path)
+	}
+	for i := len(spans) - 1; i >= 0; i-- {
+		data = deleteSpan(data, container, spans[i])
+	}
+	return data, nil
+}
+
+// deleteSpan removes the member or element with the separator before it,
+// or the separator after it if it is the first one in the container starting at container
+func deleteSpan(data []byte, container int, span valueSpan) []byte {
+	start, end := span.prevEnd, span.end
+	if start == -1 {
+		start = skipWhitespacesAt(data, container+1)
+		if next := skipWhitespacesAt(data, end); next < len(data) && data[next] == ',' {
+			end = skipWhitespacesAt(data, next+1)
+		}
+	}
+	edited := make([]byte, 0, len(data)-(end-start))
+	edited = append(edited, data[:start]...)
+	return append(edited, data[end:]...)
+}
+
+func skipWhitespacesAt(data []byte, i int) int {
+	for ; i < len(data); i++ {
+		switch data[i] {
+		case ' ', '\t', '\n', '\r':
+		default:
+			return i
+		}
+	}
+	return i
+}
@@ -0,0 +1,171 @@
+package jsoniter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ElementDecoder streams the elements of a JSON array one by one, without reading the whole array.
+// The array can be the top-level value, or selected by path like Get,
+// for example ("data", "items") streams the elements of data.items[*].
+// Only the element being decoded is kept in memory.
+type ElementDecoder struct {
+	iter   *Iterator
+	path   []interface{}
+	tokens []string // path as JSON pointer reference tokens
+	opened bool
+	done   bool
+	index  int
+}
+
+// NewElementDecoder creates an ElementDecoder reading from reader,
+// path is a sequence of object field names (string) and array indexes (int)
+func NewElementDecoder(cfg API, reader io.Reader, path ...interface{}) *ElementDecoder {
+	tokens := make([]string, len(path))
+	for i, key := range path {
+		tokens[i] = fmt.Sprint(key)
+	}
+	return &ElementDecoder{
+		iter:   Parse(cfg, reader, 4096),
+		path:   path,
+		tokens: tokens,
+		index:  -1,
+	}
+}
+
+// Index returns the index of the last element decoded
+func (decoder *ElementDecoder) Index() int {
+	return decoder.index
+}
+
+// Decode decodes the next element into obj, returns io.EOF after the last element
+func (decoder *ElementDecoder) Decode(obj interface{}) error {
+	return decoder.DecodeContext(context.Background(), obj)
+}
+
+// DecodeContext is Decode stopping with ctx.Err() if ctx is done before the next element,
+// the element being decoded is stopped as Decoder.DecodeContext
+func (decoder *ElementDecoder) DecodeContext(ctx context.Context, obj interface{}) error {
+	iter := decoder.iter
+	if iter.Error != nil && iter.Error != io.EOF {
+		return iter.Error
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	iter.SetContext(ctx)
+	defer iter.SetContext(nil)
+	if !decoder.opened {
+		decoder.opened = true
+		if !decoder.open(ctx) {
+			return decoder.error()
+		}
+	}
+	if decoder.done {
+		return io.EOF
+	}
+	if decoder.index >= 0 {
+		c := iter.nextToken()
+		switch c {
+		case ',':
+		case ']':
+			decoder.done = true
+			return io.EOF
+		case 0:
+			iter.ReportError("ElementDecoder", "expect , or ], but found end of input")
+			return decoder.error()
+		default:
+			iter.ReportError("ElementDecoder", "expect , or ], but found "+string([]byte{c}))
+			return decoder.error()
+		}
+	}
+	decoder.index++
+	iter.ReadVal(obj)
+	if iter.Error == io.EOF {
+		// the array is not closed, the last element may have been cut in the middle
+		iter.ReportError("ElementDecoder", "truncated element")
+	}
+	if iter.Error != nil {
+		iter.addErrorContext("", strconv.Itoa(decoder.index))
+		return decoder.error()
+	}
+	return nil
+}
+
+// error returns the current error, with the path to the array
+func (decoder *ElementDecoder) error() error {
+	if _, isDecodeErr := decoder.iter.Error.(*DecodeError); isDecodeErr {
+		decoder.iter.addErrorContext("", decoder.tokens...)
+	}
+	return decoder.iter.Error
+}
+
+// open locates the array by path, and reads the [ of it
+func (decoder *ElementDecoder) open(ctx context.Context) bool {
+	iter := decoder.iter
+	for i, key := range decoder.path {
+		var found bool
+		switch key := key.(type) {
+		case string:
+			found = decoder.locateField(ctx, key)
+		case int:
+			found = decoder.locateElement(ctx, key)
+		default:
+			iter.ReportError("ElementDecoder", fmt.Sprintf("unsupported path key: %v", key))
+			return false
+		}
+		if iter.Error != nil && iter.Error != io.EOF {
+			return false
+		}
+		if !found {
+			iter.ReportError("ElementDecoder", fmt.Sprintf("path %v not found", decoder.path[:i+1]))
+			return false
+		}
+	}
+	c := iter.nextToken()
+	if c != '[' {
+		iter.ReportError("ElementDecoder", "expect [, but found "+string([]byte{c}))
+		return false
+	}
+	if iter.nextToken() == ']' {
+		decoder.done = true
+		return true
+	}
+	iter.unreadByte()
+	return true
+}
+
+// locateField stops the object at the value of target, the empty key is a key as any other
+func (decoder *ElementDecoder) locateField(ctx context.Context, target string) bool {
+	found := false
+	decoder.iter.ReadObjectCB(func(iter *Iterator, field string) bool {
+		if field == target {
+			found = true
+			return false
+		}
+		if err := ctx.Err(); err != nil {
+			iter.Error = err
+			return false
+		}
+		iter.Skip()
+		return true
+	})
+	return found
+}
+
+func (decoder *ElementDecoder) locateElement(ctx context.Context, target int) bool {
+	iter := decoder.iter
+	for i := 0; iter.ReadArray(); i++ {
+		if i == target {
+			return true
+		}
+		if err := ctx.Err(); err != nil {
+			iter.Error = err
+			return false
+		}
+		iter.Skip()
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+package jsoniter
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// LineError is the error of one line read by LineDecoder.
+// The positions reported by Err are relative to the line.
+type LineError struct {
+	Line int // 1-based line number in the input
+	Err  error
+}
+
+func (err *LineError) Error() string {
+	return fmt.Sprintf("line %d: %s", err.Line, err.Err.Error())
+}
+
+// Unwrap returns the error decoding the line
+func (err *LineError) Unwrap() error {
+	return err.Err
+}
+
+// LineDecoder reads newline-delimited JSON (JSON Lines), one value per line.
+// Blank lines are ignored. A malformed line is reported as *LineError,
+// and the next Decode continues from the next line.
+type LineDecoder struct {
+	cfg           *frozenConfig
+	reader        *bufio.Reader
+	buf           []byte
+	line          int
+	maxLineSize   int
+	skipMalformed bool
+	skipped       []*LineError
+}
+
+// NewLineDecoder creates a LineDecoder reading from reader
+func NewLineDecoder(cfg API, reader io.Reader) *LineDecoder {
+	return &LineDecoder{
+		cfg:    cfg.(*frozenConfig),
+		reader: bufio.NewReaderSize(reader, 4096),
+	}
+}
+
+// MaxLineSize limits the bytes of one line, the longer line is reported as *LimitError
+// without being kept in memory. 0 means no limit.
+func (decoder *LineDecoder) MaxLineSize(size int) {
+	decoder.maxLineSize = size
+}
+
+// SkipMalformed causes Decode to skip the lines can not be decoded, instead of returning error.
+// The errors can be inspected by Skipped.
+func (decoder *LineDecoder) SkipMalformed() {
+	decoder.skipMalformed = true
+}
+
+// Skipped returns the errors of the lines skipped in SkipMalformed mode
+func (decoder *LineDecoder) Skipped() []*LineError {
+	return decoder.skipped
+}
+
+// Line returns the line number of the last line read
+func (decoder *LineDecoder) Line() int {
+	return decoder.line
+}
+
+// Decode decodes the next non-blank line into obj, returns io.EOF if there is no more line
+func (decoder *LineDecoder) Decode(obj interface{}) error {
+	for {
+		line, tooLong, err := decoder.readLine()
+		if err != nil {
+			return err
+		}
+		decoder.line++
+		var lineErr *LineError
+		if tooLong {
+			lineErr = &LineError{decoder.line, &LimitError{Limit: "MaxLineSize", Max: int64(decoder.maxLineSize)}}
+		} else if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		} else if err := decoder.cfg.Unmarshal(line, obj); err != nil {
+			lineErr = &LineError{decoder.line, err}
+		} else {
+			return nil
+		}
+		if !decoder.skipMalformed {
+			return lineErr
+		}
+		decoder.skipped = append(decoder.skipped, lineErr)
+	}
+}
+
+// readLine reads the next line without the line ending,
+// the line exceeding maxLineSize is discarded and reported as tooLong
+func (decoder *LineDecoder) readLine() (line []byte, tooLong bool, err error) {
+	if decoder.cfg.zeroCopyStrings {
+		// the decoded strings alias the buffer, it can not be reused
+		decoder.buf = nil
+	}
+	decoder.buf = decoder.buf[:0]
+	read := 0
+	for {
+		chunk, err := decoder.reader.ReadSlice('\n')
+		read += len(chunk)
+		if !tooLong {
+			decoder.buf = append(decoder.buf, chunk...)
+			if decoder.maxLineSize > 0 && len(bytes.TrimRight(decoder.buf, "\r\n")) > decoder.maxLineSize {
+				tooLong = true
+				decoder.buf = decoder.buf[:0]
+			}
+		}
+		switch err {
+		case nil:
+			return bytes.TrimRight(decoder.buf, "\r\n"), tooLong, nil
+		case bufio.ErrBufferFull:
+			continue
+		case io.EOF:
+			if read == 0 {
+				return nil, false, io.EOF
+			}
+			return bytes.TrimRight(decoder.buf, "\r\n"), tooLong, nil
+		default:
+			return nil, false, err
+		}
+	}
+}
+
+// LineEncoder writes newline-delimited JSON (JSON Lines),
+// each value is written compactly on exactly one line
+type LineEncoder struct {
+	stream *Stream
+	out    io.Writer
+}
+
+// NewLineEncoder creates a LineEncoder writing to writer, the IndentionStep of cfg is ignored
+func NewLineEncoder(cfg API, writer io.Writer) *LineEncoder {
+	frozen := cfg.(*frozenConfig)
+	if frozen.indentionStep != 0 {
+		config := frozen.configBeforeFrozen
+		config.IndentionStep = 0
+		frozen = config.frozeWithCacheReuse(frozen.extraExtensions)
+	}
+	return &LineEncoder{stream: NewStream(frozen, nil, 512), out: writer}
+}
+
+// Encode writes val and a newline
+func (encoder *LineEncoder) Encode(val interface{}) error {
+	stream := encoder.stream
+	stream.Reset(nil)
+	stream.Error = nil
+	stream.WriteVal(val)
+	if stream.Error != nil {
+		return stream.Error
+	}
+	// json.Marshaler and json.RawMessage may write whitespaces including newlines
+	line := append(compactLine(stream.Buffer()), '\n')
+	stream.SetBuffer(line)
+	_, err := encoder.out.Write(line)
+	return err
+}
+
+// compactLine removes the whitespaces outside of strings, if the value is not on one line
+func compactLine(value []byte) []byte {
+	if bytes.IndexByte(value, '\n') == -1 && bytes.IndexByte(value, '\r') == -1 {
+		return value
+	}
+	compacted := value[:0]
+	inString := false
+	escaped := false
+	for _, c := range value {
+		if inString {
+			if escaped {
+				escaped = false
+			} else if c == '\\' {
+				escaped = true
+			} else if c == '"' {
+				inString = false
+			}
+		} else {
+			switch c {
+			case ' ', '\t', '\n', '\r':
+				continue
+			case '"':
+				inString = true
+			}
+		}
+		compacted = append(compacted, c)
+	}
+	return compacted
+}
@@ -0,0 +1,505 @@
+package jsoniter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// PushEvent is the type of the events emitted by PushParser
+type PushEvent int
+
+const (
+	// PushBeginObject is {
+	PushBeginObject PushEvent = iota
+	// PushEndObject is }
+	PushEndObject
+	// PushBeginArray is [
+	PushBeginArray
+	// PushEndArray is ]
+	PushEndArray
+	// PushKey is the key of object member, the value is unescaped
+	PushKey
+	// PushString is a string, the value is unescaped
+	PushString
+	// PushNumber is a number, the value is the number as written
+	PushNumber
+	// PushBool is true or false
+	PushBool
+	// PushNull is null
+	PushNull
+)
+
+type pushState int
+
+const (
+	pushValue         pushState = iota // a value, or the next top-level value
+	pushValueOrEnd                     // after [
+	pushKeyOrEnd                       // after {
+	pushKey                            // after , in object
+	pushColon                          // after key
+	pushCommaOrEnd                     // after value in array or object
+	pushString                         // in string
+	pushStringEscape                   // after \ in string
+	pushStringUnicode                  // in \u escape in string
+	pushNumber                         // in number
+	pushLiteral                        // in true, false or null
+)
+
+// PushParser is the incremental parser fed by Write with chunks of input, instead of reading from io.Reader.
+// The chunks can be cut anywhere, including the middle of strings, numbers and escapes.
+// The input is a sequence of JSON values separated by optional whitespaces, like the input of Decoder.
+// Every value is emitted as SAX style events by OnEvent, and the completed top-level values by OnValue.
+// The relaxed syntax is not supported. MaxDepth, MaxStringLength and MaxInputBytes of the config are applied,
+// the string or number carried to the next chunk is limited by MaxStringLength before unescaping.
+type PushParser struct {
+	cfg        *frozenConfig
+	onEvent    func(event PushEvent, value string) error
+	onValue    func(iter *Iterator) error
+	state      pushState
+	isKey      bool   // the string being read is a key
+	stack      []byte // { or [ of the containers entered
+	literal    string // the literal being read
+	matched    int    // the bytes of literal or hex digits of \u escape matched
+	token      []byte // the string or number being read, carried from the previous chunks
+	inToken    bool
+	value      []byte // the top-level value being read, carried from the previous chunks
+	inValue    bool
+	offset     int64 // the offset of the chunk being parsed
+	line       int
+	lineOffset int64 // the offset of the first byte of line
+	err        error
+}
+
+// NewPushParser creates a PushParser with the config
+func NewPushParser(cfg API) *PushParser {
+	return &PushParser{cfg: cfg.(*frozenConfig), line: 1}
+}
+
+// OnEvent sets the handler of events, returning error stops the parser with the error
+func (parser *PushParser) OnEvent(handler func(event PushEvent, value string) error) {
+	parser.onEvent = handler
+}
+
+// OnValue sets the handler of the completed top-level values, iter is at the value to be read by any method,
+// such as ReadVal into typed value. Returning error or leaving error in iter stops the parser with the error.
+func (parser *PushParser) OnValue(handler func(iter *Iterator) error) {
+	parser.onValue = handler
+}
+
+// Reset makes the parser ready for another input, the handlers are kept
+func (parser *PushParser) Reset() {
+	*parser = PushParser{
+		cfg:     parser.cfg,
+		onEvent: parser.onEvent,
+		onValue: parser.onValue,
+		stack:   parser.stack[:0],
+		token:   parser.token[:0],
+		value:   parser.value[:0],
+		line:    1,
+	}
+}
+
+// Write parses the chunk. The handlers are called for every event and value completed in the chunk,
+// the number at the end of chunk is completed by the next chunk or Close.
+// Once error is returned, the parser keeps returning it until Reset.
+func (parser *PushParser) Write(chunk []byte) (int, error) {
+	if parser.err != nil {
+		return 0, parser.err
+	}
+	if limit := int64(parser.cfg.maxInputBytes) - parser.offset; int64(len(chunk)) > limit {
+		// the input is cut at MaxInputBytes, the same as Iterator
+		n, err := parser.Write(chunk[:limit])
+		if err != nil {
+			return n, err
+		}
+		parser.reportLimitError(chunk[n:], 0, "MaxInputBytes", parser.cfg.maxInputBytes)
+		parser.fail(0)
+		return n, parser.err
+	}
+	tokenStart, valueStart := 0, 0
+	i := 0
+	for i < len(chunk) {
+		c := chunk[i]
+		switch parser.state {
+		case pushString:
+			switch c {
+			case '"':
+				i++
+				if !parser.completeString(parser.tokenOf(chunk, tokenStart, i), chunk, i) {
+					return parser.fail(i)
+				}
+				if !parser.isKey && !parser.completeValue(chunk, valueStart, i) {
+					return parser.fail(i)
+				}
+			case '\\':
+				parser.state = pushStringEscape
+				i++
+			default:
+				if c < ' ' {
+					parser.reportError(chunk, i, "control character in string")
+					return parser.fail(i)
+				}
+				i++
+			}
+			continue
+		case pushStringEscape:
+			switch c {
+			case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
+				parser.state = pushString
+			case 'u':
+				parser.state, parser.matched = pushStringUnicode, 0
+			default:
+				parser.reportError(chunk, i, `invalid escape char after \`)
+				return parser.fail(i)
+			}
+			i++
+			continue
+		case pushStringUnicode:
+			if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+				parser.reportError(chunk, i, "expects 0~9 or a~f, but found "+string([]byte{c}))
+				return parser.fail(i)
+			}
+			i++
+			parser.matched++
+			if parser.matched == 4 {
+				parser.state = pushString
+			}
+			continue
+		case pushNumber:
+			switch c {
+			case '+', '-', '.', 'e', 'E', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+				i++
+				continue
+			}
+			// the byte after the number is parsed in the next state
+			if !parser.completeNumber(parser.tokenOf(chunk, tokenStart, i), chunk, i) ||
+				!parser.completeValue(chunk, valueStart, i) {
+				return parser.fail(i)
+			}
+			continue
+		case pushLiteral:
+			if c != parser.literal[parser.matched] {
+				parser.reportError(chunk, i, "invalid literal, expect "+parser.literal)
+				return parser.fail(i)
+			}
+			i++
+			parser.matched++
+			if parser.matched < len(parser.literal) {
+				continue
+			}
+			event, value := PushNull, ""
+			if parser.literal != "null" {
+				event, value = PushBool, parser.literal
+			}
+			if !parser.emit(event, value) || !parser.completeValue(chunk, valueStart, i) {
+				return parser.fail(i)
+			}
+			continue
+		}
+		switch c {
+		case ' ', '\t', '\r':
+			i++
+			continue
+		case '\n':
+			i++
+			parser.line++
+			parser.lineOffset = parser.offset + int64(i)
+			continue
+		}
+		switch parser.state {
+		case pushKeyOrEnd, pushKey:
+			if c == '}' && parser.state == pushKeyOrEnd {
+				break
+			}
+			if c != '"' {
+				parser.reportError(chunk, i, "expect \" for object key, but found "+string([]byte{c}))
+				return parser.fail(i)
+			}
+			parser.state, parser.isKey = pushString, true
+			parser.inToken, tokenStart = true, i
+			i++
+			continue
+		case pushColon:
+			if c != ':' {
+				parser.reportError(chunk, i, "expect : after object key, but found "+string([]byte{c}))
+				return parser.fail(i)
+			}
+			parser.state = pushValue
+			i++
+			continue
+		case pushCommaOrEnd:
+			switch c {
+			case ',':
+				if parser.stack[len(parser.stack)-1] == '{' {
+					parser.state = pushKey
+				} else {
+					parser.state = pushValue
+				}
+				i++
+				continue
+			case '}', ']':
+			default:
+				parser.reportError(chunk, i, "expect , or } or ], but found "+string([]byte{c}))
+				return parser.fail(i)
+			}
+		}
+		if len(parser.stack) == 0 && !parser.inValue {
+			parser.inValue, valueStart = true, i
+		}
+		switch c {
+		case '{', '[':
+			if len(parser.stack) >= parser.cfg.maxDepth {
+				parser.reportLimitError(chunk, i, "MaxDepth", parser.cfg.maxDepth)
+				return parser.fail(i)
+			}
+			parser.stack = append(parser.stack, c)
+			i++
+			event := PushBeginObject
+			parser.state = pushKeyOrEnd
+			if c == '[' {
+				event, parser.state = PushBeginArray, pushValueOrEnd
+			}
+			if !parser.emit(event, "") {
+				return parser.fail(i)
+			}
+		case '}', ']':
+			closing := parser.state == pushCommaOrEnd ||
+				c == '}' && parser.state == pushKeyOrEnd || c == ']' && parser.state == pushValueOrEnd
+			if !closing || parser.stack[len(parser.stack)-1] != c-2 { // '{' is '}'-2 and '[' is ']'-2
+				parser.reportError(chunk, i, "unexpected "+string([]byte{c}))
+				return parser.fail(i)
+			}
+			parser.stack = parser.stack[:len(parser.stack)-1]
+			i++
+			event := PushEndObject
+			if c == ']' {
+				event = PushEndArray
+			}
+			if !parser.emit(event, "") || !parser.completeValue(chunk, valueStart, i) {
+				return parser.fail(i)
+			}
+		case '"':
+			parser.state, parser.isKey = pushString, false
+			parser.inToken, tokenStart = true, i
+			i++
+		case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			parser.state = pushNumber
+			parser.inToken, tokenStart = true, i
+			i++
+		case 't', 'f', 'n':
+			parser.state = pushLiteral
+			switch c {
+			case 't':
+				parser.literal = "true"
+			case 'f':
+				parser.literal = "false"
+			default:
+				parser.literal = "null"
+			}
+			parser.matched = 1
+			i++
+		default:
+			parser.reportError(chunk, i, "invalid character "+string([]byte{c})+" looking for beginning of value")
+			return parser.fail(i)
+		}
+	}
+	// carry the incomplete token and value to the next chunk
+	if parser.inToken {
+		carried := len(parser.token) + len(chunk) - tokenStart
+		if parser.state != pushNumber {
+			carried-- // the opening quote
+		}
+		if carried > parser.cfg.maxStringLength {
+			parser.reportLimitError(chunk, len(chunk), "MaxStringLength", parser.cfg.maxStringLength)
+			return parser.fail(len(chunk))
+		}
+		parser.token = append(parser.token, chunk[tokenStart:]...)
+	}
+	if parser.inValue && parser.onValue != nil {
+		parser.value = append(parser.value, chunk[valueStart:]...)
+	}
+	parser.offset += int64(len(chunk))
+	return len(chunk), nil
+}
+
+// Close ends the input, it completes the number at the end, and reports error if the last value is incomplete
+func (parser *PushParser) Close() error {
+	if parser.err != nil {
+		return parser.err
+	}
+	if parser.state == pushNumber && len(parser.stack) == 0 {
+		if !parser.completeNumber(parser.tokenOf(nil, 0, 0), nil, 0) || !parser.completeValue(nil, 0, 0) {
+			return parser.err
+		}
+	}
+	if parser.inValue {
+		parser.reportError(nil, 0, "unexpected end of input")
+	}
+	return parser.err
+}
+
+// tokenOf returns the token ended at end of chunk
+func (parser *PushParser) tokenOf(chunk []byte, tokenStart int, end int) []byte {
+	parser.inToken = false
+	if len(parser.token) == 0 {
+		return chunk[tokenStart:end]
+	}
+	token := append(parser.token, chunk[tokenStart:end]...)
+	parser.token = parser.token[:0]
+	return token
+}
+
+func (parser *PushParser) completeString(token []byte, chunk []byte, i int) bool {
+	isKey := parser.isKey
+	if isKey {
+		parser.state = pushColon
+	} else {
+		parser.state = pushCommaOrEnd
+	}
+	if parser.onEvent == nil {
+		return true
+	}
+	iter := parser.cfg.BorrowIterator(token)
+	defer parser.cfg.ReturnIterator(iter)
+	str := iter.ReadString()
+	if iter.Error != nil && iter.Error != io.EOF {
+		msg := iter.Error.Error()
+		if decodeErr, isDecodeErr := iter.Error.(*DecodeError); isDecodeErr {
+			msg = decodeErr.Message
+		}
+		parser.reportError(chunk, i, msg)
+		if isLimitError(iter.Error) {
+			parser.err.(*DecodeError).Err = iter.Error.(*DecodeError).Err
+		}
+		return false
+	}
+	if parser.cfg.zeroCopyStrings {
+		// the chunk is usually reused by the caller
+		str = string([]byte(str))
+	}
+	if isKey {
+		return parser.emit(PushKey, str)
+	}
+	return parser.emit(PushString, str)
+}
+
+func (parser *PushParser) completeNumber(token []byte, chunk []byte, i int) bool {
+	parser.state = pushCommaOrEnd
+	if !isJSONNumber(token) {
+		parser.reportError(chunk, i, "invalid number "+string(token))
+		return false
+	}
+	if parser.onEvent == nil {
+		return true
+	}
+	return parser.emit(PushNumber, string(token))
+}
+
+// completeValue calls OnValue if the top-level value ended before i
+func (parser *PushParser) completeValue(chunk []byte, valueStart int, i int) bool {
+	if len(parser.stack) != 0 {
+		parser.state = pushCommaOrEnd
+		return true
+	}
+	parser.state = pushValue
+	parser.inValue = false
+	if parser.onValue == nil {
+		return true
+	}
+	value := chunk[valueStart:i]
+	if len(parser.value) != 0 {
+		value = append(parser.value, value...)
+		parser.value = parser.value[:0]
+	}
+	if parser.cfg.zeroCopyStrings {
+		// the strings read by OnValue share the bytes, which are reused for the next values
+		value = append([]byte(nil), value...)
+	}
+	iter := parser.cfg.BorrowIterator(value)
+	defer parser.cfg.ReturnIterator(iter)
+	err := parser.onValue(iter)
+	if err == nil && iter.Error != nil && iter.Error != io.EOF {
+		err = iter.Error
+	}
+	parser.err = err
+	return err == nil
+}
+
+func (parser *PushParser) emit(event PushEvent, value string) bool {
+	if parser.onEvent == nil {
+		return true
+	}
+	parser.err = parser.onEvent(event, value)
+	return parser.err == nil
+}
+
+func (parser *PushParser) fail(i int) (int, error) {
+	if parser.err == nil {
+		parser.err = errors.New("PushParser: stopped")
+	}
+	parser.offset += int64(i)
+	return i, parser.err
+}
+
+func (parser *PushParser) reportError(chunk []byte, i int, msg string) {
+	peekStart := i - 10
+	if peekStart < 0 {
+		peekStart = 0
+	}
+	peekEnd := i + 10
+	if peekEnd > len(chunk) {
+		peekEnd = len(chunk)
+	}
+	offset := parser.offset + int64(i)
+	parser.err = &DecodeError{
+		Operation: "PushParser",
+		Message:   msg,
+		Offset:    offset,
+		Line:      parser.line,
+		Column:    int(offset-parser.lineOffset) + 1,
+		snippet:   fmt.Sprintf("error found in #%v byte of ...|%s|...", i-peekStart, string(chunk[peekStart:peekEnd])),
+	}
+}
+
+// reportLimitError reports the limit set in Config is exceeded at i, with LimitError as the underlying error
+func (parser *PushParser) reportLimitError(chunk []byte, i int, limit string, max int) {
+	limitErr := &LimitError{Limit: limit, Max: int64(max)}
+	parser.reportError(chunk, i, limitErr.Error())
+	parser.err.(*DecodeError).Err = limitErr
+}
+
+// isJSONNumber validates the syntax of JSON number
+func isJSONNumber(str []byte) bool {
+	i := 0
+	if i < len(str) && str[i] == '-' {
+		i++
+	}
+	digits := func() int {
+		start := i
+		for i < len(str) && str[i] >= '0' && str[i] <= '9' {
+			i++
+		}
+		return i - start
+	}
+	intStart := i
+	if n := digits(); n == 0 || n > 1 && str[intStart] == '0' {
+		return false
+	}
+	if i < len(str) && str[i] == '.' {
+		i++
+		if digits() == 0 {
+			return false
+		}
+	}
+	if i < len(str) && (str[i] == 'e' || str[i] == 'E') {
+		i++
+		if i < len(str) && (str[i] == '+' || str[i] == '-') {
+			i++
+		}
+		if digits() == 0 {
+			return false
+		}
+	}
+	return i == len(str)
+}
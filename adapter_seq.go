@@ -0,0 +1,139 @@
+package jsoniter
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// recordSeparator starts every JSON text in RFC 7464 JSON text sequences
+const recordSeparator = 0x1E
+
+// ErrTruncatedRecord is reported for the top-level number, true, false or null
+// not followed by whitespace, which may have been truncated as RFC 7464 describes
+var ErrTruncatedRecord = errors.New("truncated record")
+
+// SequenceError is the error of one record read by SequenceDecoder
+type SequenceError struct {
+	Record int // 1-based index of the record in the sequence
+	Err    error
+}
+
+func (err *SequenceError) Error() string {
+	return fmt.Sprintf("record %d: %s", err.Record, err.Err.Error())
+}
+
+// Unwrap returns the error decoding the record
+func (err *SequenceError) Unwrap() error {
+	return err.Err
+}
+
+// SequenceDecoder reads RFC 7464 JSON text sequences (application/json-seq).
+// A truncated or corrupted record is reported as *SequenceError,
+// and the next Decode resynchronizes at the next record separator.
+type SequenceDecoder struct {
+	cfg    *frozenConfig
+	reader *bufio.Reader
+	buf    []byte
+	record int
+}
+
+// NewSequenceDecoder creates a SequenceDecoder reading from reader
+func NewSequenceDecoder(cfg API, reader io.Reader) *SequenceDecoder {
+	return &SequenceDecoder{
+		cfg:    cfg.(*frozenConfig),
+		reader: bufio.NewReaderSize(reader, 4096),
+	}
+}
+
+// Decode decodes the next record into obj, returns io.EOF if there is no more record
+func (decoder *SequenceDecoder) Decode(obj interface{}) error {
+	for {
+		record, err := decoder.readRecord()
+		if err != nil {
+			return err
+		}
+		record = bytes.TrimLeft(record, " \t\r\n")
+		if len(record) == 0 {
+			continue // empty record, such as consecutive separators
+		}
+		decoder.record++
+		if isTruncatedRecord(record) {
+			return &SequenceError{decoder.record, ErrTruncatedRecord}
+		}
+		// decoded by the pooled Iterator
+		if err := decoder.cfg.Unmarshal(record, obj); err != nil {
+			return &SequenceError{decoder.record, err}
+		}
+		return nil
+	}
+}
+
+// readRecord reads the bytes until the next record separator
+func (decoder *SequenceDecoder) readRecord() ([]byte, error) {
+	if decoder.cfg.zeroCopyStrings {
+		// the decoded strings alias the buffer, it can not be reused
+		decoder.buf = nil
+	}
+	decoder.buf = decoder.buf[:0]
+	read := 0
+	for {
+		chunk, err := decoder.reader.ReadSlice(recordSeparator)
+		read += len(chunk)
+		decoder.buf = append(decoder.buf, chunk...)
+		switch err {
+		case nil:
+			return decoder.buf[:len(decoder.buf)-1], nil
+		case bufio.ErrBufferFull:
+			continue
+		case io.EOF:
+			if read == 0 {
+				return nil, io.EOF
+			}
+			return decoder.buf, nil
+		default:
+			return nil, err
+		}
+	}
+}
+
+// isTruncatedRecord tells if the record is a number, true, false or null without trailing whitespace
+func isTruncatedRecord(record []byte) bool {
+	switch record[0] {
+	case '"', '[', '{':
+		return false
+	}
+	switch record[len(record)-1] {
+	case ' ', '\t', '\r', '\n':
+		return false
+	}
+	return true
+}
+
+// SequenceEncoder writes RFC 7464 JSON text sequences (application/json-seq)
+type SequenceEncoder struct {
+	cfg *frozenConfig
+	out io.Writer
+}
+
+// NewSequenceEncoder creates a SequenceEncoder writing to writer
+func NewSequenceEncoder(cfg API, writer io.Writer) *SequenceEncoder {
+	return &SequenceEncoder{cfg: cfg.(*frozenConfig), out: writer}
+}
+
+// Encode writes the record separator, val and a newline.
+// Nothing is written if val can not be encoded.
+func (encoder *SequenceEncoder) Encode(val interface{}) error {
+	stream := encoder.cfg.BorrowStream(nil)
+	defer encoder.cfg.ReturnStream(stream)
+	stream.writeByte(recordSeparator)
+	stream.WriteVal(val)
+	stream.writeByte('\n')
+	if stream.Error != nil {
+		return stream.Error
+	}
+	_, err := encoder.out.Write(stream.Buffer())
+	return err
+}
@@ -32,6 +32,8 @@ type Any interface {
 	Keys() []string
 	GetInterface() interface{}
 	WriteTo(stream *Stream)
+	Query(path string) Any
+	GetPointer(pointer string) Any
 }
 
 type baseAny struct{}
@@ -79,7 +81,7 @@ func WrapFloat64(val float64) Any {
 
 // WrapString turn string into Any interface
 func WrapString(val string) Any {
-	return &stringAny{baseAny{}, val}
+	return &stringAny{baseAny{}, ConfigDefault.(*frozenConfig), val, nil}
 }
 
 // Wrap turn a go object into Any interface
@@ -155,7 +157,7 @@ func (iter *Iterator) readAny() Any {
 	switch c {
 	case '"':
 		iter.unreadByte()
-		return &stringAny{baseAny{}, iter.ReadString()}
+		return &stringAny{baseAny{}, iter.cfg, iter.ReadString(), nil}
 	case 'n':
 		iter.skipThreeBytes('u', 'l', 'l') // null
 		return &nilAny{}
@@ -173,6 +175,11 @@ func (iter *Iterator) readAny() Any {
 		return iter.readNumberAny(false)
 	case 0:
 		return &invalidAny{baseAny{}, errors.New("input is empty")}
+	case '\'':
+		if iter.cfg.relaxed {
+			return &stringAny{baseAny{}, iter.cfg, iter.readSingleQuotedString(), nil}
+		}
+		return iter.readNumberAny(true)
 	default:
 		return iter.readNumberAny(true)
 	}
@@ -189,55 +196,113 @@ func (iter *Iterator) readObjectAny() Any {
 	iter.startCapture(iter.head - 1)
 	iter.skipObject()
 	lazyBuf := iter.stopCapture()
-	return &objectLazyAny{baseAny{}, iter.cfg, lazyBuf, nil}
+	return &objectLazyAny{baseAny{}, iter.cfg, lazyBuf, nil, lazyIndex{}}
 }
 
 func (iter *Iterator) readArrayAny() Any {
 	iter.startCapture(iter.head - 1)
 	iter.skipArray()
 	lazyBuf := iter.stopCapture()
-	return &arrayLazyAny{baseAny{}, iter.cfg, lazyBuf, nil}
+	return &arrayLazyAny{baseAny{}, iter.cfg, lazyBuf, nil, lazyIndex{}}
 }
 
+// locateObjectField returns the value of target by the DuplicateKeyPolicy,
+// the duplicate key is reported as error by DuplicateKeysError
 func locateObjectField(iter *Iterator, target string) []byte {
-	var found []byte
+	return iter.copySpan(locateObjectFieldSpan(iter, target))
+}
+
+func locateArrayElement(iter *Iterator, target int) []byte {
+	return iter.copySpan(locateArrayElementSpan(iter, target))
+}
+
+// valueSpan is the position of the value located in the buffer of iterator reading bytes
+type valueSpan struct {
+	start   int // -1 if not found
+	end     int
+	prevEnd int // the end of the previous value in the container, or of the last value if not found, -1 if none
+}
+
+func locateObjectFieldSpan(iter *Iterator, target string) valueSpan {
+	span := valueSpan{start: -1, prevEnd: -1}
+	lastEnd := -1
+	policy := iter.cfg.duplicateKeys
 	iter.ReadObjectCB(func(iter *Iterator, field string) bool {
-		if field == target {
-			found = iter.SkipAndReturnBytes()
+		if field != target {
+			iter.Skip()
+			lastEnd = iter.head
+			return true
+		}
+		if span.start == -1 || policy == DuplicateKeysLastWins {
+			span = iter.skipSpan(lastEnd)
+		} else if policy == DuplicateKeysError {
+			iter.ReportError("locateObjectField", duplicateKeyMessage(target))
 			return false
+		} else {
+			iter.Skip()
 		}
-		iter.Skip()
-		return true
+		lastEnd = iter.head
+		// the later keys are checked only by DuplicateKeysError and DuplicateKeysLastWins
+		return policy == DuplicateKeysError || policy == DuplicateKeysLastWins
 	})
-	return found
+	if span.start == -1 {
+		span.prevEnd = lastEnd
+	}
+	return span
 }
 
-func locateArrayElement(iter *Iterator, target int) []byte {
-	var found []byte
+func locateArrayElementSpan(iter *Iterator, target int) valueSpan {
+	span := valueSpan{start: -1, prevEnd: -1}
 	n := 0
 	iter.ReadArrayCB(func(iter *Iterator) bool {
 		if n == target {
-			found = iter.SkipAndReturnBytes()
+			span = iter.skipSpan(span.prevEnd)
 			return false
 		}
 		iter.Skip()
+		span.prevEnd = iter.head
 		n++
 		return true
 	})
-	return found
+	return span
+}
+
+// skipSpan skips the next value and returns its position, without the whitespaces before it
+func (iter *Iterator) skipSpan(prevEnd int) valueSpan {
+	iter.WhatIsNext()
+	start := iter.head
+	iter.Skip()
+	return valueSpan{start, iter.head, prevEnd}
 }
 
+func (iter *Iterator) copySpan(span valueSpan) []byte {
+	if span.start == -1 {
+		return nil
+	}
+	copied := make([]byte, span.end-span.start)
+	copy(copied, iter.buf[span.start:span.end])
+	return copied
+}
+
+// locatePath gets path from the next value by skipping the values before the path in one pass,
+// without building the structural index, which pays only for the lazy Any accessed repeatedly
 func locatePath(iter *Iterator, path []interface{}) Any {
 	for i, pathKeyObj := range path {
 		switch pathKey := pathKeyObj.(type) {
 		case string:
 			valueBytes := locateObjectField(iter, pathKey)
+			if iter.Error != nil && iter.Error != io.EOF {
+				return &invalidAny{baseAny{}, iter.Error}
+			}
 			if valueBytes == nil {
 				return newInvalidAny(path[i:])
 			}
 			iter.ResetBytes(valueBytes)
 		case int:
 			valueBytes := locateArrayElement(iter, pathKey)
+			if iter.Error != nil && iter.Error != io.EOF {
+				return &invalidAny{baseAny{}, iter.Error}
+			}
 			if valueBytes == nil {
 				return newInvalidAny(path[i:])
 			}
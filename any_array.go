@@ -1,15 +1,17 @@
 package jsoniter
 
 import (
+	"io"
 	"reflect"
 	"unsafe"
 )
 
 type arrayLazyAny struct {
 	baseAny
-	cfg *frozenConfig
-	buf []byte
-	err error
+	cfg   *frozenConfig
+	buf   []byte
+	err   error
+	index lazyIndex
 }
 
 func (any *arrayLazyAny) ValueType() ValueType {
@@ -102,9 +104,20 @@ func (any *arrayLazyAny) Get(path ...interface{}) Any {
 	}
 	switch firstPath := path[0].(type) {
 	case int:
+		if index, at := any.index.get(any.cfg, any.buf); index != nil {
+			if value, valueOpen, ok := index.element(any.cfg, at, firstPath); ok {
+				if value == nil {
+					return newInvalidAny(path)
+				}
+				return getPath(index.valueAny(any.cfg, value, valueOpen), path[1:])
+			}
+		}
 		iter := any.cfg.BorrowIterator(any.buf)
 		defer any.cfg.ReturnIterator(iter)
 		valueBytes := locateArrayElement(iter, firstPath)
+		if iter.Error != nil && iter.Error != io.EOF {
+			return &invalidAny{baseAny{}, iter.Error}
+		}
 		if valueBytes == nil {
 			return newInvalidAny(path)
 		}
@@ -112,9 +125,21 @@ func (any *arrayLazyAny) Get(path ...interface{}) Any {
 		return locatePath(iter, path[1:])
 	case int32:
 		if '*' == firstPath {
+			arr := make([]Any, 0)
+			if index, at := any.index.get(any.cfg, any.buf); index != nil {
+				if index.eachElement(any.cfg, at, func(value []byte, valueOpen int) bool {
+					found := getPath(index.valueAny(any.cfg, value, valueOpen), path[1:])
+					if found.ValueType() != InvalidValue {
+						arr = append(arr, found)
+					}
+					return true
+				}) {
+					return wrapArray(arr)
+				}
+				arr = make([]Any, 0)
+			}
 			iter := any.cfg.BorrowIterator(any.buf)
 			defer any.cfg.ReturnIterator(iter)
-			arr := make([]Any, 0)
 			iter.ReadArrayCB(func(iter *Iterator) bool {
 				found := iter.readAny().Get(path[1:]...)
 				if found.ValueType() != InvalidValue {
@@ -132,6 +157,15 @@ func (any *arrayLazyAny) Get(path ...interface{}) Any {
 
 func (any *arrayLazyAny) Size() int {
 	size := 0
+	if index, at := any.index.get(any.cfg, any.buf); index != nil {
+		if index.eachElement(any.cfg, at, func(value []byte, valueOpen int) bool {
+			size++
+			return true
+		}) {
+			return size
+		}
+		size = 0
+	}
 	iter := any.cfg.BorrowIterator(any.buf)
 	defer any.cfg.ReturnIterator(iter)
 	iter.ReadArrayCB(func(iter *Iterator) bool {
@@ -142,6 +176,27 @@ func (any *arrayLazyAny) Size() int {
 	return size
 }
 
+// children returns the elements in order, without parsing them
+func (any *arrayLazyAny) children() []Any {
+	var elements []Any
+	if index, at := any.index.get(any.cfg, any.buf); index != nil {
+		if index.eachElement(any.cfg, at, func(value []byte, valueOpen int) bool {
+			elements = append(elements, index.valueAny(any.cfg, value, valueOpen))
+			return true
+		}) {
+			return elements
+		}
+		elements = nil
+	}
+	iter := any.cfg.BorrowIterator(any.buf)
+	defer any.cfg.ReturnIterator(iter)
+	iter.ReadArrayCB(func(iter *Iterator) bool {
+		elements = append(elements, iter.readAny())
+		return true
+	})
+	return elements
+}
+
 func (any *arrayLazyAny) WriteTo(stream *Stream) {
 	stream.Write(any.buf)
 }
@@ -152,6 +207,14 @@ func (any *arrayLazyAny) GetInterface() interface{} {
 	return iter.Read()
 }
 
+func (any *arrayLazyAny) Query(path string) Any {
+	return queryAny(any, path)
+}
+
+func (any *arrayLazyAny) GetPointer(pointer string) Any {
+	return getPointer(any, pointer)
+}
+
 type arrayAny struct {
 	baseAny
 	val reflect.Value
@@ -276,3 +339,11 @@ func (any *arrayAny) WriteTo(stream *Stream) {
 func (any *arrayAny) GetInterface() interface{} {
 	return any.val.Interface()
 }
+
+func (any *arrayAny) Query(path string) Any {
+	return queryAny(any, path)
+}
+
+func (any *arrayAny) GetPointer(pointer string) Any {
+	return getPointer(any, pointer)
+}
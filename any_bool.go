@@ -60,6 +60,14 @@ func (any *trueAny) GetInterface() interface{} {
 	return true
 }
 
+func (any *trueAny) Query(path string) Any {
+	return queryAny(any, path)
+}
+
+func (any *trueAny) GetPointer(pointer string) Any {
+	return getPointer(any, pointer)
+}
+
 func (any *trueAny) ValueType() ValueType {
 	return BoolValue
 }
@@ -128,6 +136,14 @@ func (any *falseAny) GetInterface() interface{} {
 	return false
 }
 
+func (any *falseAny) Query(path string) Any {
+	return queryAny(any, path)
+}
+
+func (any *falseAny) GetPointer(pointer string) Any {
+	return getPointer(any, pointer)
+}
+
 func (any *falseAny) ValueType() ValueType {
 	return BoolValue
 }
@@ -81,3 +81,11 @@ func (any *floatAny) WriteTo(stream *Stream) {
 func (any *floatAny) GetInterface() interface{} {
 	return any.val
 }
+
+func (any *floatAny) Query(path string) Any {
+	return queryAny(any, path)
+}
+
+func (any *floatAny) GetPointer(pointer string) Any {
+	return getPointer(any, pointer)
+}
@@ -72,3 +72,11 @@ func (any *int32Any) Parse() *Iterator {
 func (any *int32Any) GetInterface() interface{} {
 	return any.val
 }
+
+func (any *int32Any) Query(path string) Any {
+	return queryAny(any, path)
+}
+
+func (any *int32Any) GetPointer(pointer string) Any {
+	return getPointer(any, pointer)
+}
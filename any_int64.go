@@ -72,3 +72,11 @@ func (any *int64Any) Parse() *Iterator {
 func (any *int64Any) GetInterface() interface{} {
 	return any.val
 }
+
+func (any *int64Any) Query(path string) Any {
+	return queryAny(any, path)
+}
+
+func (any *int64Any) GetPointer(pointer string) Any {
+	return getPointer(any, pointer)
+}
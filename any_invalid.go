@@ -80,3 +80,11 @@ func (any *invalidAny) Parse() *Iterator {
 func (any *invalidAny) GetInterface() interface{} {
 	return nil
 }
+
+func (any *invalidAny) Query(path string) Any {
+	return any
+}
+
+func (any *invalidAny) GetPointer(pointer string) Any {
+	return any
+}
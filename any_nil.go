@@ -67,3 +67,11 @@ func (any *nilAny) Parse() *Iterator {
 func (any *nilAny) GetInterface() interface{} {
 	return nil
 }
+
+func (any *nilAny) Query(path string) Any {
+	return queryAny(any, path)
+}
+
+func (any *nilAny) GetPointer(pointer string) Any {
+	return getPointer(any, pointer)
+}
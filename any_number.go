@@ -112,6 +112,15 @@ func (any *numberLazyAny) ToString() string {
 	return *(*string)(unsafe.Pointer(&any.buf))
 }
 
+func (any *numberLazyAny) ToVal(obj interface{}) {
+	iter := any.cfg.BorrowIterator(any.buf)
+	defer any.cfg.ReturnIterator(iter)
+	iter.ReadVal(obj)
+	if iter.Error != nil && iter.Error != io.EOF {
+		any.err = iter.Error
+	}
+}
+
 func (any *numberLazyAny) WriteTo(stream *Stream) {
 	stream.Write(any.buf)
 }
@@ -121,3 +130,11 @@ func (any *numberLazyAny) GetInterface() interface{} {
 	defer any.cfg.ReturnIterator(iter)
 	return iter.Read()
 }
+
+func (any *numberLazyAny) Query(path string) Any {
+	return queryAny(any, path)
+}
+
+func (any *numberLazyAny) GetPointer(pointer string) Any {
+	return getPointer(any, pointer)
+}
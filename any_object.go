@@ -1,15 +1,18 @@
 package jsoniter
 
 import (
+	"errors"
+	"io"
 	"reflect"
 	"unsafe"
 )
 
 type objectLazyAny struct {
 	baseAny
-	cfg *frozenConfig
-	buf []byte
-	err error
+	cfg   *frozenConfig
+	buf   []byte
+	err   error
+	index lazyIndex
 }
 
 func (any *objectLazyAny) ValueType() ValueType {
@@ -76,9 +79,39 @@ func (any *objectLazyAny) Get(path ...interface{}) Any {
 	}
 	switch firstPath := path[0].(type) {
 	case string:
+		if index, at := any.index.get(any.cfg, any.buf); index != nil {
+			policy := any.cfg.duplicateKeys
+			var found []byte
+			foundOpen := -1
+			duplicate := false
+			if index.eachField(any.cfg, at, func(key []byte, value []byte, valueOpen int) bool {
+				if !keyEquals(any.cfg, key, firstPath) {
+					return true
+				}
+				if found == nil || policy == DuplicateKeysLastWins {
+					found, foundOpen = value, valueOpen
+				} else if policy == DuplicateKeysError {
+					duplicate = true
+					return false
+				}
+				// the later keys are checked only by DuplicateKeysError and DuplicateKeysLastWins
+				return policy == DuplicateKeysError || policy == DuplicateKeysLastWins
+			}) {
+				if duplicate {
+					return &invalidAny{baseAny{}, errors.New(duplicateKeyMessage(firstPath))}
+				}
+				if found == nil {
+					return newInvalidAny(path)
+				}
+				return getPath(index.valueAny(any.cfg, found, foundOpen), path[1:])
+			}
+		}
 		iter := any.cfg.BorrowIterator(any.buf)
 		defer any.cfg.ReturnIterator(iter)
 		valueBytes := locateObjectField(iter, firstPath)
+		if iter.Error != nil && iter.Error != io.EOF {
+			return &invalidAny{baseAny{}, iter.Error}
+		}
 		if valueBytes == nil {
 			return newInvalidAny(path)
 		}
@@ -87,15 +120,51 @@ func (any *objectLazyAny) Get(path ...interface{}) Any {
 	case int32:
 		if '*' == firstPath {
 			mappedAll := map[string]Any{}
+			keys := objectKeys{policy: any.cfg.duplicateKeys}
+			var duplicate string
+			if index, at := any.index.get(any.cfg, any.buf); index != nil {
+				if index.eachField(any.cfg, at, func(key []byte, value []byte, valueOpen int) bool {
+					field := unquoteKey(any.cfg, key)
+					if keys.policy != DuplicateKeysDefault && keys.duplicate(field) {
+						if keys.policy == DuplicateKeysError {
+							duplicate = field
+							return false
+						}
+						if keys.policy == DuplicateKeysFirstWins {
+							return true
+						}
+					}
+					mapped := getPath(index.valueAny(any.cfg, value, valueOpen), path[1:])
+					if mapped.ValueType() != InvalidValue {
+						mappedAll[field] = mapped
+					}
+					return true
+				}) {
+					if duplicate != "" {
+						return &invalidAny{baseAny{}, errors.New(duplicateKeyMessage(duplicate))}
+					}
+					return wrapMap(mappedAll)
+				}
+				mappedAll = map[string]Any{}
+				keys = objectKeys{policy: any.cfg.duplicateKeys}
+			}
 			iter := any.cfg.BorrowIterator(any.buf)
 			defer any.cfg.ReturnIterator(iter)
 			iter.ReadMapCB(func(iter *Iterator, field string) bool {
-				mapped := locatePath(iter, path[1:])
+				if keys.policy != DuplicateKeysDefault && keys.skip(iter, field) {
+					iter.Skip()
+					return iter.Error == nil || iter.Error == io.EOF
+				}
+				// locatePath would reset the iterator reading the object
+				mapped := getPath(iter.readAny(), path[1:])
 				if mapped.ValueType() != InvalidValue {
 					mappedAll[field] = mapped
 				}
 				return true
 			})
+			if iter.Error != nil && iter.Error != io.EOF && keys.policy == DuplicateKeysError {
+				return &invalidAny{baseAny{}, iter.Error}
+			}
 			return wrapMap(mappedAll)
 		}
 		return newInvalidAny(path)
@@ -105,7 +174,32 @@ func (any *objectLazyAny) Get(path ...interface{}) Any {
 }
 
 func (any *objectLazyAny) Keys() []string {
+	keys := any.keysWithDuplicates()
+	if any.cfg.duplicateKeys == DuplicateKeysDefault {
+		return keys
+	}
+	// the duplicate keys are listed once with the policy
+	seen := objectKeys{policy: any.cfg.duplicateKeys}
+	unique := keys[:0]
+	for _, key := range keys {
+		if !seen.duplicate(key) {
+			unique = append(unique, key)
+		}
+	}
+	return unique
+}
+
+func (any *objectLazyAny) keysWithDuplicates() []string {
 	keys := []string{}
+	if index, at := any.index.get(any.cfg, any.buf); index != nil {
+		if index.eachField(any.cfg, at, func(key []byte, value []byte, valueOpen int) bool {
+			keys = append(keys, unquoteKey(any.cfg, key))
+			return true
+		}) {
+			return keys
+		}
+		keys = []string{}
+	}
 	iter := any.cfg.BorrowIterator(any.buf)
 	defer any.cfg.ReturnIterator(iter)
 	iter.ReadMapCB(func(iter *Iterator, field string) bool {
@@ -117,7 +211,19 @@ func (any *objectLazyAny) Keys() []string {
 }
 
 func (any *objectLazyAny) Size() int {
+	if any.cfg.duplicateKeys != DuplicateKeysDefault {
+		return len(any.Keys())
+	}
 	size := 0
+	if index, at := any.index.get(any.cfg, any.buf); index != nil {
+		if index.eachField(any.cfg, at, func(key []byte, value []byte, valueOpen int) bool {
+			size++
+			return true
+		}) {
+			return size
+		}
+		size = 0
+	}
 	iter := any.cfg.BorrowIterator(any.buf)
 	defer any.cfg.ReturnIterator(iter)
 	iter.ReadObjectCB(func(iter *Iterator, field string) bool {
@@ -128,6 +234,27 @@ func (any *objectLazyAny) Size() int {
 	return size
 }
 
+// children returns the member values in order, without parsing them
+func (any *objectLazyAny) children() []Any {
+	var values []Any
+	if index, at := any.index.get(any.cfg, any.buf); index != nil {
+		if index.eachField(any.cfg, at, func(key []byte, value []byte, valueOpen int) bool {
+			values = append(values, index.valueAny(any.cfg, value, valueOpen))
+			return true
+		}) {
+			return values
+		}
+		values = nil
+	}
+	iter := any.cfg.BorrowIterator(any.buf)
+	defer any.cfg.ReturnIterator(iter)
+	iter.ReadMapCB(func(iter *Iterator, field string) bool {
+		values = append(values, iter.readAny())
+		return true
+	})
+	return values
+}
+
 func (any *objectLazyAny) WriteTo(stream *Stream) {
 	stream.Write(any.buf)
 }
@@ -138,6 +265,14 @@ func (any *objectLazyAny) GetInterface() interface{} {
 	return iter.Read()
 }
 
+func (any *objectLazyAny) Query(path string) Any {
+	return queryAny(any, path)
+}
+
+func (any *objectLazyAny) GetPointer(pointer string) Any {
+	return getPointer(any, pointer)
+}
+
 type objectAny struct {
 	baseAny
 	err error
@@ -257,6 +392,14 @@ func (any *objectAny) GetInterface() interface{} {
 	return any.val.Interface()
 }
 
+func (any *objectAny) Query(path string) Any {
+	return queryAny(any, path)
+}
+
+func (any *objectAny) GetPointer(pointer string) Any {
+	return getPointer(any, pointer)
+}
+
 type mapAny struct {
 	baseAny
 	err error
@@ -372,3 +515,11 @@ func (any *mapAny) WriteTo(stream *Stream) {
 func (any *mapAny) GetInterface() interface{} {
 	return any.val.Interface()
 }
+
+func (any *mapAny) Query(path string) Any {
+	return queryAny(any, path)
+}
+
+func (any *mapAny) GetPointer(pointer string) Any {
+	return getPointer(any, pointer)
+}
@@ -2,12 +2,15 @@ package jsoniter
 
 import (
 	"fmt"
+	"io"
 	"strconv"
 )
 
 type stringAny struct {
 	baseAny
+	cfg *frozenConfig
 	val string
+	err error
 }
 
 func (any *stringAny) Get(path ...interface{}) Any {
@@ -30,7 +33,7 @@ func (any *stringAny) MustBeValid() Any {
 }
 
 func (any *stringAny) LastError() error {
-	return nil
+	return any.err
 }
 
 func (any *stringAny) ToBool() bool {
@@ -157,6 +160,18 @@ func (any *stringAny) ToString() string {
 	return any.val
 }
 
+func (any *stringAny) ToVal(obj interface{}) {
+	stream := any.cfg.BorrowStream(nil)
+	defer any.cfg.ReturnStream(stream)
+	stream.WriteString(any.val)
+	iter := any.cfg.BorrowIterator(stream.Buffer())
+	defer any.cfg.ReturnIterator(iter)
+	iter.ReadVal(obj)
+	if iter.Error != nil && iter.Error != io.EOF {
+		any.err = iter.Error
+	}
+}
+
 func (any *stringAny) WriteTo(stream *Stream) {
 	stream.WriteString(any.val)
 }
@@ -164,3 +179,11 @@ func (any *stringAny) WriteTo(stream *Stream) {
 func (any *stringAny) GetInterface() interface{} {
 	return any.val
 }
+
+func (any *stringAny) Query(path string) Any {
+	return queryAny(any, path)
+}
+
+func (any *stringAny) GetPointer(pointer string) Any {
+	return getPointer(any, pointer)
+}
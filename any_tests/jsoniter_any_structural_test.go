@@ -0,0 +1,58 @@
+package any_tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_lazy_any_jumps_by_structural_index(t *testing.T) {
+	should := require.New(t)
+	padding := strings.Repeat("x", 100)
+	any := jsoniter.Get([]byte(`{"pad":"` + padding + `{[\"\\",
+		"ab" : [ 1 , {"c":"}]"} , [2,3] , "s" ],
+		"empty": {}, "list": []}`))
+	should.Equal(4, any.Size())
+	should.Equal([]string{"pad", "ab", "empty", "list"}, any.Keys())
+	should.Equal(4, any.Get("ab").Size())
+	should.Equal(1, any.Get("ab", 0).ToInt())
+	should.Equal("}]", any.Get("ab", 1, "c").ToString())
+	should.Equal(3, any.Get("ab", 2, 1).ToInt())
+	should.Equal("s", any.Get("ab", 3).ToString())
+	should.Equal(jsoniter.InvalidValue, any.Get("ab", 4).ValueType())
+	should.Equal(jsoniter.InvalidValue, any.Get("ab", 3, "x").ValueType())
+	should.Equal(jsoniter.InvalidValue, any.Get("missing").ValueType())
+	should.Equal(0, any.Get("empty").Size())
+	should.Equal(0, any.Get("list").Size())
+	should.Equal(`[2,3]`, any.Get("ab", 2).ToString())
+	should.Equal(1, any.Get("ab", '*', "c").Size())
+	should.Equal("}]", any.Get("ab", '*', "c").Get(0).ToString())
+	should.Equal([]string{"ab"}, any.Get('*', 1).Keys())
+	should.Equal(padding+`{["\`, any.Get("pad").ToString())
+}
+
+func Test_lazy_any_malformed_falls_back(t *testing.T) {
+	should := require.New(t)
+	any := jsoniter.Get([]byte(`{"a":1,"b" 2}`))
+	should.Equal(1, any.Get("a").ToInt())
+	should.Equal(jsoniter.InvalidValue, any.Get("b").ValueType())
+}
+
+func Test_get_path(t *testing.T) {
+	should := require.New(t)
+	data := []byte(`{"a": [{"b": 1}, {"b": 2}], "c": [10, 20, [30, 40]]}`)
+	should.Equal(2, jsoniter.Get(data, "a", 1, "b").ToInt())
+	should.Equal(40, jsoniter.Get(data, "c", 2, 1).ToInt())
+	should.Equal(jsoniter.InvalidValue, jsoniter.Get(data, "c", 3).ValueType())
+	mapped := jsoniter.Get([]byte(`{"a": {"b": 1}, "c": {"b": 2}}`), '*', "b")
+	should.Equal(1, mapped.Get("a").ToInt())
+	should.Equal(2, mapped.Get("c").ToInt())
+	// the relaxed input is located by skipping the values as well
+	relaxed := jsoniter.Config{Relaxed: true}.Froze()
+	mapped = relaxed.Get([]byte(`{a: {b: 1}, /* c */ c: {b: 2},}`), '*', "b")
+	should.Equal(1, mapped.Get("a").ToInt())
+	should.Equal(2, mapped.Get("c").ToInt())
+	should.Equal(2, relaxed.Get([]byte(`{a: [1, 2,]}`), "a", 1).ToInt())
+}
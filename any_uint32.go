@@ -72,3 +72,11 @@ func (any *uint32Any) Parse() *Iterator {
 func (any *uint32Any) GetInterface() interface{} {
 	return any.val
 }
+
+func (any *uint32Any) Query(path string) Any {
+	return queryAny(any, path)
+}
+
+func (any *uint32Any) GetPointer(pointer string) Any {
+	return getPointer(any, pointer)
+}
@@ -72,3 +72,11 @@ func (any *uint64Any) Parse() *Iterator {
 func (any *uint64Any) GetInterface() interface{} {
 	return any.val
 }
+
+func (any *uint64Any) Query(path string) Any {
+	return queryAny(any, path)
+}
+
+func (any *uint64Any) GetPointer(pointer string) Any {
+	return getPointer(any, pointer)
+}
@@ -0,0 +1,100 @@
+package test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+type bigNumbers struct {
+	Int      big.Int
+	Float    big.Float
+	Rat      big.Rat
+	IntPtr   *big.Int
+	FloatPtr *big.Float
+	RatPtr   *big.Rat
+}
+
+func Test_big_numbers_as_numbers(t *testing.T) {
+	should := require.New(t)
+	input := `{"Int":123456789012345678901234567890,"Float":1.00000000000000000000000001,` +
+		`"Rat":-0.125,"IntPtr":-1,"FloatPtr":1e+100,"RatPtr":null}`
+	var val bigNumbers
+	should.Nil(jsoniter.UnmarshalFromString(input, &val))
+	should.Equal("123456789012345678901234567890", val.Int.String())
+	should.Equal("1.00000000000000000000000001", val.Float.Text('g', -1))
+	should.Equal("-1/8", val.Rat.String())
+	should.Equal("-1", val.IntPtr.String())
+	should.Nil(val.RatPtr)
+	output, err := jsoniter.MarshalToString(val)
+	should.Nil(err)
+	should.Equal(input, output)
+	var fromStrings bigNumbers
+	should.Nil(jsoniter.UnmarshalFromString(`{"Int":"12","Float":"1.5","Rat":"0.5"}`, &fromStrings))
+	should.Equal("12", fromStrings.Int.String())
+	should.Equal("1/2", fromStrings.Rat.String())
+	// the strings are parsed as UnmarshalText
+	should.Nil(jsoniter.UnmarshalFromString(`{"Int":"0x1f","Rat":"1/3"}`, &fromStrings))
+	should.Equal("31", fromStrings.Int.String())
+	should.Equal("1/3", fromStrings.Rat.String())
+	should.NotNil(jsoniter.UnmarshalFromString(`{"Int":1.5}`, &fromStrings))
+	should.NotNil(jsoniter.UnmarshalFromString(`{"Int":true}`, &fromStrings))
+}
+
+func Test_big_numbers_string_tag(t *testing.T) {
+	should := require.New(t)
+	type TestObject struct {
+		Int   big.Int    `json:",string"`
+		Float *big.Float `json:",string"`
+	}
+	var val TestObject
+	should.Nil(jsoniter.UnmarshalFromString(`{"Int":"98765432109876543210","Float":"2.5"}`, &val))
+	should.Equal("98765432109876543210", val.Int.String())
+	should.Equal("2.5", val.Float.String())
+	output, err := jsoniter.MarshalToString(val)
+	should.Nil(err)
+	should.Equal(`{"Int":"98765432109876543210","Float":"2.5"}`, output)
+}
+
+func Test_big_numbers_as_strings(t *testing.T) {
+	should := require.New(t)
+	// the same as encoding/json, by MarshalJSON and MarshalText
+	val := bigNumbers{IntPtr: big.NewInt(7), RatPtr: big.NewRat(1, 3)}
+	val.Int.SetInt64(-3)
+	val.Float.SetFloat64(1.5)
+	val.Rat.SetFrac64(3, 4)
+	expected, err := json.Marshal(&val)
+	should.Nil(err)
+	input := `{"Int":12,"Float":"2.5","Rat":"1/3","IntPtr":null,"FloatPtr":"0.25","RatPtr":"-7/2"}`
+	var expectedDecoded bigNumbers
+	should.Nil(json.Unmarshal([]byte(input), &expectedDecoded))
+	for _, api := range []jsoniter.API{
+		jsoniter.Config{BigNumbersAsStrings: true}.Froze(), jsoniter.ConfigCompatibleWithStandardLibrary,
+	} {
+		output, err := api.Marshal(&val)
+		should.Nil(err)
+		should.Equal(string(expected), string(output))
+		var decoded bigNumbers
+		should.Nil(api.UnmarshalFromString(input, &decoded))
+		should.Equal(expectedDecoded.Int.String(), decoded.Int.String())
+		should.Equal(expectedDecoded.Float.String(), decoded.Float.String())
+		should.Equal(expectedDecoded.Rat.String(), decoded.Rat.String())
+		should.Nil(decoded.IntPtr)
+		should.Equal(expectedDecoded.FloatPtr.String(), decoded.FloatPtr.String())
+		should.Equal(expectedDecoded.RatPtr.String(), decoded.RatPtr.String())
+	}
+}
+
+func Test_big_numbers_not_exact(t *testing.T) {
+	should := require.New(t)
+	_, err := jsoniter.Marshal(big.NewRat(1, 3))
+	should.NotNil(err)
+	_, err = jsoniter.Marshal(new(big.Float).SetInf(false))
+	should.NotNil(err)
+	output, err := jsoniter.MarshalToString(big.NewRat(-7, 20))
+	should.Nil(err)
+	should.Equal("-0.35", output)
+}
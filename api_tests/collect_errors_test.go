@@ -0,0 +1,111 @@
+package test
+
+import (
+	"bytes"
+	"testing"
+	"testing/iotest"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+type collectErrorsItem struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type collectErrorsOrder struct {
+	ID    int                 `json:"id"`
+	Items []collectErrorsItem `json:"items"`
+	Tags  map[string]int      `json:"tags"`
+	Next  *collectErrorsOrder `json:"next"`
+}
+
+func Test_collect_all_errors(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{CollectAllErrors: true}.Froze()
+	input := `{"id":"1","items":[{"name":"a","count":1},{"name":2,"count":{"x":[1]}},{"name":"c","count":3}],` +
+		`"tags":{"x":1,"y":true},"next":{"id":2,"items":[{"count":"many"}]}}`
+	var obj collectErrorsOrder
+	err := api.Unmarshal([]byte(input), &obj)
+	should.NotNil(err)
+	errs, ok := err.(jsoniter.DecodeErrors)
+	should.True(ok)
+	pointers := []string{}
+	for _, decodeErr := range errs {
+		pointers = append(pointers, decodeErr.Pointer)
+	}
+	should.Equal([]string{"/id", "/items/1/name", "/items/1/count", "/tags/y", "/next/items/0/count"}, pointers)
+	should.Contains(err.Error(), "5 errors: /id: ")
+	// the values can be decoded are kept
+	should.Equal(3, len(obj.Items))
+	should.Equal("a", obj.Items[0].Name)
+	should.Equal(3, obj.Items[2].Count)
+	should.Equal(1, obj.Tags["x"])
+	should.Equal(2, obj.Next.ID)
+}
+
+func Test_collect_all_errors_stops_at_malformed_input(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{CollectAllErrors: true}.Froze()
+	var obj []int
+	err := api.Unmarshal([]byte(`[1,"a",2,{"x":}]`), &obj)
+	errs, ok := err.(jsoniter.DecodeErrors)
+	should.True(ok)
+	should.Equal(2, len(errs))
+	should.Equal("/1", errs[0].Pointer)
+	should.Equal("/3", errs[1].Pointer)
+	should.Nil(api.Unmarshal([]byte(`[1,2]`), &obj))
+	should.Equal([]int{1, 2}, obj)
+}
+
+func Test_collect_all_errors_from_decoder(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{CollectAllErrors: true}.Froze()
+	decoder := api.NewDecoder(bytes.NewBufferString(`[1,"a"] [true,2]`))
+	var obj []int
+	err := decoder.Decode(&obj)
+	should.Equal(1, len(err.(jsoniter.DecodeErrors)))
+	err = decoder.Decode(&obj)
+	should.Equal("/0", err.(jsoniter.DecodeErrors)[0].Pointer)
+	should.Equal(2, obj[1])
+}
+
+func Test_collect_all_errors_from_reader(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{CollectAllErrors: true}.Froze()
+	type numbers struct {
+		A, B, C, D int
+		Items      []int
+	}
+	input := `{"A":"x","B":2,"C":"y","D":5,"Items":[1,{"x":"long enough to be refilled"},3]}`
+	var expected numbers
+	expectedErr := api.Unmarshal([]byte(input), &expected)
+	should.Equal(3, len(expectedErr.(jsoniter.DecodeErrors)))
+	// the same errors are collected, wherever the buffer of reader is refilled
+	var obj numbers
+	err := api.NewDecoder(iotest.OneByteReader(bytes.NewBufferString(input))).Decode(&obj)
+	errs := err.(jsoniter.DecodeErrors)
+	should.Equal(3, len(errs))
+	for i, expectedDecodeErr := range expectedErr.(jsoniter.DecodeErrors) {
+		should.Equal(expectedDecodeErr.Pointer, errs[i].Pointer)
+		should.Equal(expectedDecodeErr.Message, errs[i].Message)
+		should.Equal(expectedDecodeErr.Offset, errs[i].Offset)
+		should.Equal(expectedDecodeErr.Line, errs[i].Line)
+	}
+	should.Equal(expected, obj)
+	should.Equal(numbers{B: 2, D: 5, Items: []int{1, 0, 3}}, obj)
+	decoder := api.NewDecoder(iotest.OneByteReader(bytes.NewBufferString(`[1,"a"] [true,2]`)))
+	var slice []int
+	should.Equal("/1", decoder.Decode(&slice).(jsoniter.DecodeErrors)[0].Pointer)
+	should.Equal("/0", decoder.Decode(&slice).(jsoniter.DecodeErrors)[0].Pointer)
+	should.Equal(2, slice[1])
+}
+
+func Test_default_config_stops_at_first_error(t *testing.T) {
+	should := require.New(t)
+	var obj collectErrorsOrder
+	err := jsoniter.Unmarshal([]byte(`{"id":"1","tags":{"y":true}}`), &obj)
+	_, ok := err.(*jsoniter.DecodeError)
+	should.True(ok)
+}
@@ -0,0 +1,125 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingReader returns its input, then blocks until closed
+type blockingReader struct {
+	input  io.Reader
+	closed chan struct{}
+}
+
+func (reader *blockingReader) Read(p []byte) (int, error) {
+	n, err := reader.input.Read(p)
+	if n > 0 || err != io.EOF {
+		return n, err
+	}
+	<-reader.closed
+	return 0, io.EOF
+}
+
+// cancellingReader cancels when read the second time
+type cancellingReader struct {
+	input  io.Reader
+	cancel context.CancelFunc
+	reads  int
+}
+
+func (reader *cancellingReader) Read(p []byte) (int, error) {
+	reader.reads++
+	if reader.reads == 2 {
+		reader.cancel()
+	}
+	return reader.input.Read(p)
+}
+
+func Test_decode_context_deadline_while_reading(t *testing.T) {
+	should := require.New(t)
+	reader := &blockingReader{strings.NewReader(`{"a":[1,2,`), make(chan struct{})}
+	defer close(reader.closed)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	var val map[string][]int
+	err := jsoniter.NewDecoder(reader).DecodeContext(ctx, &val)
+	should.True(errors.Is(err, context.DeadlineExceeded))
+	decodeErr, ok := err.(*jsoniter.DecodeError)
+	should.True(ok)
+	should.Equal(int64(9), decodeErr.Offset)
+	should.Equal("/a/2", decodeErr.Pointer)
+}
+
+func Test_decode_context_canceled_in_long_array(t *testing.T) {
+	should := require.New(t)
+	input := "[" + strings.Repeat("1,", 100000) + "1]"
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	decoder := jsoniter.ConfigDefault.NewDecoder(&cancellingReader{bytes.NewBufferString(input), cancel, 0})
+	var val []int
+	err := decoder.DecodeContext(ctx, &val)
+	should.True(errors.Is(err, context.Canceled))
+	decodeErr, ok := err.(*jsoniter.DecodeError)
+	should.True(ok)
+	should.True(decodeErr.Offset > 0 && decodeErr.Offset < int64(len(input)), decodeErr.Offset)
+	should.Contains(err.Error(), "context canceled")
+}
+
+// goroutineReader records the goroutines calling Read
+type goroutineReader struct {
+	input      io.Reader
+	goroutines map[string]bool
+}
+
+func (reader *goroutineReader) Read(p []byte) (int, error) {
+	stack := make([]byte, 64)
+	stack = stack[:runtime.Stack(stack, false)]
+	reader.goroutines[string(stack[:bytes.IndexByte(stack, '[')])] = true
+	return reader.input.Read(p[:1])
+}
+
+func Test_decode_context_reads_in_one_goroutine(t *testing.T) {
+	should := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reader := &goroutineReader{strings.NewReader(`{"a":[1,2,3],"b":"hello"}`), map[string]bool{}}
+	var val map[string]interface{}
+	should.Nil(jsoniter.NewDecoder(reader).DecodeContext(ctx, &val))
+	should.Equal("hello", val["b"])
+	should.Len(reader.goroutines, 1)
+}
+
+func Test_decode_context_done_before_decoding(t *testing.T) {
+	should := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	decoder := jsoniter.NewDecoder(strings.NewReader(`[1] [2]`))
+	var val []int
+	should.Equal(context.Canceled, decoder.DecodeContext(ctx, &val))
+	should.Nil(decoder.DecodeContext(context.Background(), &val))
+	should.Equal([]int{1}, val)
+	should.Nil(decoder.Decode(&val))
+	should.Equal([]int{2}, val)
+}
+
+func Test_iterator_context(t *testing.T) {
+	should := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	iter := jsoniter.ParseString(jsoniter.ConfigDefault, `{"a":[`+strings.Repeat(`{},`, 10000)+`{}]}`)
+	iter.SetContext(ctx)
+	should.Equal(ctx, iter.Context())
+	cancel()
+	iter.Skip()
+	should.True(errors.Is(iter.Error, context.Canceled))
+	iter = jsoniter.ParseString(jsoniter.ConfigDefault, `[1,2,3]`)
+	should.Equal(context.Background(), iter.Context())
+}
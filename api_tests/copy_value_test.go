@@ -0,0 +1,74 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_copy_value_to_writer(t *testing.T) {
+	should := require.New(t)
+	value := `{ "a" : [ 1, 2.5e3 , "x\" ] {" ], "b":{ }, "c" : [ ] ,
+	"d": { "e" : null , "f" : [true,false] }, "g": "é \\" }`
+	input := ` ` + value + ` , 12 `
+	var compact, indented bytes.Buffer
+	should.Nil(json.Compact(&compact, []byte(value)))
+	should.Nil(json.Indent(&indented, []byte(value), "> ", "\t"))
+	for _, bufSize := range []int{1, 3, 16, 4096} {
+		newIter := func() *jsoniter.Iterator {
+			return jsoniter.Parse(jsoniter.ConfigDefault, iotest.OneByteReader(strings.NewReader(input)), bufSize)
+		}
+		var output bytes.Buffer
+		iter := newIter()
+		written := iter.CopyValueTo(&output)
+		should.Nil(iter.Error)
+		should.Equal(value, output.String())
+		should.Equal(int64(len(value)), written)
+		should.Equal(jsoniter.ValueType(jsoniter.InvalidValue), iter.WhatIsNext())
+
+		output.Reset()
+		iter = newIter()
+		iter.CopyCompactValueTo(&output)
+		should.Nil(iter.Error)
+		should.Equal(compact.String(), output.String())
+
+		output.Reset()
+		iter = newIter()
+		iter.CopyIndentedValueTo(&output, "> ", "\t")
+		should.Nil(iter.Error)
+		should.Equal(indented.String(), output.String())
+	}
+}
+
+func Test_copy_value_to_writer_in_array(t *testing.T) {
+	should := require.New(t)
+	iter := jsoniter.Parse(jsoniter.ConfigDefault, strings.NewReader(`[{"a":1}, "b" ,3]`), 2)
+	var outputs []string
+	for iter.ReadArray() {
+		var output bytes.Buffer
+		iter.CopyValueTo(&output)
+		outputs = append(outputs, output.String())
+	}
+	should.Nil(iter.Error)
+	should.Equal([]string{`{"a":1}`, `"b"`, `3`}, outputs)
+}
+
+func Test_copy_value_to_writer_errors(t *testing.T) {
+	should := require.New(t)
+	for _, input := range []string{`{"a":}`, `[1,]`, `"abc`, `tru`, ``, `{"a" 1}`} {
+		var output bytes.Buffer
+		iter := jsoniter.Parse(jsoniter.ConfigDefault, strings.NewReader(input), 2)
+		iter.CopyValueTo(&output)
+		should.NotNil(iter.Error, input)
+	}
+	failure := errors.New("broken pipe")
+	iter := jsoniter.Parse(jsoniter.ConfigDefault, strings.NewReader(`[1,2,3]`), 2)
+	iter.CopyValueTo(failingWriter{failure})
+	should.True(errors.Is(iter.Error, failure))
+}
@@ -0,0 +1,139 @@
+package test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_decimal_parse_and_string(t *testing.T) {
+	should := require.New(t)
+	for input, expected := range map[string]string{
+		"0":       "0",
+		"-0":      "0",
+		"0.00":    "0.00",
+		"12.30":   "12.30",
+		"-0.005":  "-0.005",
+		"1e3":     "1000",
+		"1.5E-3":  "0.0015",
+		"-25e+1":  "-250",
+		"100":     "100",
+		"0.1e1":   "1",
+		"3.14159": "3.14159",
+		"123456789012345678901234567890.123456789": "123456789012345678901234567890.123456789",
+	} {
+		val, err := jsoniter.ParseDecimal(input)
+		should.Nil(err, input)
+		should.Equal(expected, val.String(), input)
+	}
+	for _, input := range []string{"", "-", "01", "1.", ".5", "1e", "1e+", "+1", "1x", "NaN", "1e99999999"} {
+		_, err := jsoniter.ParseDecimal(input)
+		should.NotNil(err, input)
+	}
+}
+
+func Test_decimal_arithmetic(t *testing.T) {
+	should := require.New(t)
+	a := jsoniter.MustParseDecimal("10.25")
+	b := jsoniter.MustParseDecimal("0.1")
+	should.Equal("10.35", a.Add(b).String())
+	should.Equal("10.15", a.Sub(b).String())
+	should.Equal("-10.15", b.Sub(a).Neg().Neg().String())
+	should.Equal("1.025", a.Mul(b).String())
+	should.Equal("102.50", a.Quo(b, 2, jsoniter.RoundHalfEven).String())
+	should.Equal("3.33", jsoniter.NewDecimal(10, 0).Quo(jsoniter.NewDecimal(3, 0), 2, jsoniter.RoundHalfEven).String())
+	should.Equal("3.34", jsoniter.NewDecimal(10, 0).Quo(jsoniter.NewDecimal(3, 0), 2, jsoniter.RoundUp).String())
+	should.Equal("-0.67", jsoniter.NewDecimal(-2, 0).Quo(jsoniter.NewDecimal(3, 0), 2, jsoniter.RoundHalfUp).String())
+	should.Equal(1, a.Cmp(b))
+	should.Equal(-1, b.Cmp(a))
+	should.True(jsoniter.MustParseDecimal("1.50").Equal(jsoniter.MustParseDecimal("1.5")))
+	should.NotEqual(jsoniter.MustParseDecimal("1.50"), jsoniter.MustParseDecimal("1.5"))
+	should.Equal(jsoniter.MustParseDecimal("1.50"), jsoniter.MustParseDecimal("1.50"))
+	should.Panics(func() { a.Quo(jsoniter.Decimal{}, 2, jsoniter.RoundDown) })
+}
+
+func Test_decimal_rounding(t *testing.T) {
+	should := require.New(t)
+	modes := []jsoniter.RoundingMode{jsoniter.RoundHalfEven, jsoniter.RoundHalfUp, jsoniter.RoundDown,
+		jsoniter.RoundUp, jsoniter.RoundFloor, jsoniter.RoundCeiling}
+	for input, expected := range map[string][]string{
+		"2.5":   {"2", "3", "2", "3", "2", "3"},
+		"3.5":   {"4", "4", "3", "4", "3", "4"},
+		"-2.5":  {"-2", "-3", "-2", "-3", "-3", "-2"},
+		"2.51":  {"3", "3", "2", "3", "2", "3"},
+		"-2.49": {"-2", "-2", "-2", "-3", "-3", "-2"},
+		"7":     {"7", "7", "7", "7", "7", "7"},
+	} {
+		for i, mode := range modes {
+			should.Equal(expected[i], jsoniter.MustParseDecimal(input).Rescale(0, mode).String(), input)
+		}
+	}
+	should.Equal("1.20", jsoniter.MustParseDecimal("1.2").Rescale(2, jsoniter.RoundDown).String())
+	should.Equal("1200", jsoniter.MustParseDecimal("1234").Round(-2).String())
+	should.Equal(int32(-2), jsoniter.MustParseDecimal("1234").Round(-2).Scale())
+}
+
+func Test_decimal_codec(t *testing.T) {
+	should := require.New(t)
+	type Order struct {
+		Price    jsoniter.Decimal
+		Quantity jsoniter.Decimal  `json:",string"`
+		Discount *jsoniter.Decimal `json:",omitempty"`
+	}
+	var order Order
+	should.Nil(jsoniter.UnmarshalFromString(
+		`{"Price":19.990000000000000000001,"Quantity":"3","Discount":null}`, &order))
+	should.Equal("19.990000000000000000001", order.Price.String())
+	should.Equal("3", order.Quantity.String())
+	should.Nil(order.Discount)
+	output, err := jsoniter.MarshalToString(order)
+	should.Nil(err)
+	should.Equal(`{"Price":19.990000000000000000001,"Quantity":"3"}`, output)
+	output, err = jsoniter.MarshalToString(jsoniter.MustParseDecimal("1e-7"))
+	should.Nil(err)
+	should.Equal(`0.0000001`, output)
+	should.NotNil(jsoniter.UnmarshalFromString(`{"Price":"abc"}`, &order))
+	should.NotNil(jsoniter.UnmarshalFromString(`{"Price":true}`, &order))
+
+	var fromStd Order
+	should.Nil(json.Unmarshal([]byte(`{"Price":"1.10","Quantity":"2"}`), &fromStd))
+	should.Equal("1.10", fromStd.Price.String())
+	stdOutput, err := json.Marshal(fromStd.Price)
+	should.Nil(err)
+	should.Equal(`1.10`, string(stdOutput))
+}
+
+func Test_decimal_map_key(t *testing.T) {
+	should := require.New(t)
+	var val map[jsoniter.Decimal]string
+	should.Nil(jsoniter.UnmarshalFromString(`{"1.50":"a","2":"b"}`, &val))
+	should.Equal("a", val[jsoniter.MustParseDecimal("1.50")])
+	should.Equal("b", val[jsoniter.NewDecimal(2, 0)])
+	output, err := jsoniter.Config{SortMapKeys: true}.Froze().MarshalToString(val)
+	should.Nil(err)
+	should.Equal(`{"1.50":"a","2":"b"}`, output)
+	should.NotNil(jsoniter.UnmarshalFromString(`{"x":"a"}`, &val))
+}
+
+func Test_decimal_any_to_val(t *testing.T) {
+	should := require.New(t)
+	input := []byte(`{"total":1234.5600000000000001,"tax":"0.07"}`)
+	var total, tax jsoniter.Decimal
+	jsoniter.Get(input, "total").ToVal(&total)
+	should.Equal("1234.5600000000000001", total.String())
+	jsoniter.Get(input, "tax").ToVal(&tax)
+	should.Equal("0.07", tax.String())
+	invalid := jsoniter.Get([]byte(`{"tax":"x"}`), "tax")
+	invalid.ToVal(&tax)
+	should.NotNil(invalid.LastError())
+	// the string is decoded by the config of Any, big.Int does not accept strings as encoding/json
+	var count big.Int
+	jsoniter.Get([]byte(`{"count":"12"}`), "count").ToVal(&count)
+	should.Equal("12", count.String())
+	quoted := jsoniter.ConfigCompatibleWithStandardLibrary.Get([]byte(`{"count":"12"}`), "count")
+	quoted.ToVal(&count)
+	should.NotNil(quoted.LastError())
+}
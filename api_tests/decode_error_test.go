@@ -0,0 +1,55 @@
+package test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_decode_error_pointer(t *testing.T) {
+	should := require.New(t)
+	type Item struct {
+		Count int `json:"count"`
+	}
+	type Order struct {
+		Items map[string][]Item `json:"items"`
+	}
+	input := "{\n  \"items\": {\n    \"a/b\": [{\"count\": 1}, {\"count\": \"x\"}]\n  }\n}"
+	var obj Order
+	err := jsoniter.Unmarshal([]byte(input), &obj)
+	should.NotNil(err)
+	decodeErr, ok := err.(*jsoniter.DecodeError)
+	should.True(ok)
+	should.Equal("/items/a~1b/1/count", decodeErr.Pointer)
+	should.Equal(3, decodeErr.Line)
+	should.Equal(int64(bytes.Index([]byte(input), []byte(`"x"`))), decodeErr.Offset)
+	should.Equal(37, decodeErr.Column)
+	should.Contains(err.Error(), "Count: ")
+}
+
+func Test_decode_error_from_reader(t *testing.T) {
+	should := require.New(t)
+	input := "[\n" + string(bytes.Repeat([]byte("1,\n"), 100)) + "true]"
+	var obj []int
+	iter := jsoniter.Parse(jsoniter.ConfigDefault, bytes.NewBufferString(input), 16)
+	iter.ReadVal(&obj)
+	should.NotNil(iter.Error)
+	decodeErr, ok := iter.Error.(*jsoniter.DecodeError)
+	should.True(ok)
+	should.Equal("/100", decodeErr.Pointer)
+	should.Equal(102, decodeErr.Line)
+	should.Equal(1, decodeErr.Column)
+	should.Equal(int64(len(input)-len("true]")), decodeErr.Offset)
+}
+
+func Test_decode_error_interface(t *testing.T) {
+	should := require.New(t)
+	var obj interface{}
+	err := jsoniter.UnmarshalFromString(`{"a":[1,{"b":tru}]}`, &obj)
+	decodeErr, ok := err.(*jsoniter.DecodeError)
+	should.True(ok)
+	should.Equal("/a/1/b", decodeErr.Pointer)
+	should.Equal(1, decodeErr.Line)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"github.com/json-iterator/go"
 	"github.com/stretchr/testify/require"
+	"io"
 	"io/ioutil"
 	"testing"
 )
@@ -62,3 +63,84 @@ func Test_decoder_more(t *testing.T) {
 	decoder := jsoniter.NewDecoder(bytes.NewBufferString("abcde"))
 	should.True(decoder.More())
 }
+
+func Test_decoder_token(t *testing.T) {
+	should := require.New(t)
+	input := `{"a": [1, "b", true, null, {"c": -2.5}], "d": {}} [] "e"`
+	decoder1 := json.NewDecoder(bytes.NewBufferString(input))
+	decoder2 := jsoniter.NewDecoder(bytes.NewBufferString(input))
+	for {
+		token1, err1 := decoder1.Token()
+		token2, err2 := decoder2.Token()
+		should.Equal(err1, err2)
+		should.Equal(token1, token2)
+		if err1 != nil {
+			break
+		}
+	}
+}
+
+func Test_decoder_token_use_number(t *testing.T) {
+	should := require.New(t)
+	decoder := jsoniter.NewDecoder(bytes.NewBufferString(`[123, 4.5]`))
+	decoder.UseNumber()
+	tokens := []json.Token{}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		should.Nil(err)
+		tokens = append(tokens, token)
+	}
+	should.Equal([]json.Token{json.Delim('['), json.Number("123"), json.Number("4.5"), json.Delim(']')}, tokens)
+}
+
+func Test_decoder_token_mixed_with_decode(t *testing.T) {
+	should := require.New(t)
+	decoder := jsoniter.NewDecoder(bytes.NewBufferString(`{"items": [{"id": 1}, {"id": 2}], "total": 2}`))
+	type Item struct {
+		ID int `json:"id"`
+	}
+	token, err := decoder.Token()
+	should.Nil(err)
+	should.Equal(json.Delim('{'), token)
+	token, err = decoder.Token()
+	should.Nil(err)
+	should.Equal("items", token)
+	token, err = decoder.Token()
+	should.Nil(err)
+	should.Equal(json.Delim('['), token)
+	items := []Item{}
+	for decoder.More() {
+		var item Item
+		should.Nil(decoder.Decode(&item))
+		items = append(items, item)
+	}
+	should.Equal([]Item{{1}, {2}}, items)
+	token, err = decoder.Token()
+	should.Nil(err)
+	should.Equal(json.Delim(']'), token)
+	token, err = decoder.Token()
+	should.Nil(err)
+	should.Equal("total", token)
+	var total int
+	should.Nil(decoder.Decode(&total))
+	should.Equal(2, total)
+	token, err = decoder.Token()
+	should.Nil(err)
+	should.Equal(json.Delim('}'), token)
+	_, err = decoder.Token()
+	should.Equal(io.EOF, err)
+}
+
+func Test_decoder_token_invalid(t *testing.T) {
+	should := require.New(t)
+	decoder := jsoniter.NewDecoder(bytes.NewBufferString(`[1}`))
+	_, err := decoder.Token()
+	should.Nil(err)
+	_, err = decoder.Token()
+	should.Nil(err)
+	_, err = decoder.Token()
+	should.NotNil(err)
+}
@@ -0,0 +1,76 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_duplicate_keys_error(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{DuplicateKeys: jsoniter.DuplicateKeysError}.Froze()
+	var obj struct {
+		Field string
+		Other int
+	}
+	err := api.UnmarshalFromString(`{"field":"a","other":1,"Field":"b"}`, &obj)
+	should.Contains(err.Error(), "found duplicate key: field")
+	should.Nil(api.UnmarshalFromString(`{"field":"a","other":1,"unknown":{"field":1}}`, &obj))
+	should.NotNil(api.UnmarshalFromString(`{"unknown":1,"unknown":2}`, &obj))
+	var m map[string]int
+	should.Contains(api.UnmarshalFromString(`{"a":1,"b":2,"a":3}`, &m).Error(), "found duplicate key: a")
+	var numeric map[int]int
+	should.NotNil(api.UnmarshalFromString(`{"1":1,"01":2}`, &numeric))
+	var generic interface{}
+	should.NotNil(api.UnmarshalFromString(`[{"a":1,"a":2}]`, &generic))
+	should.False(api.Valid([]byte(`{"a":{"b":1,"b":2}}`)))
+	should.True(api.Valid([]byte(`{"a":{"b":1},"b":2}`)))
+	any := api.Get([]byte(`{"a":1,"b":{"c":1},"a":2}`))
+	should.Equal(jsoniter.InvalidValue, any.Get("a").ValueType())
+	should.Equal(1, any.Get("b", "c").ToInt())
+	should.Equal([]string{"a", "b"}, any.Keys())
+	should.Equal(2, any.Size())
+	should.Equal(jsoniter.InvalidValue, any.Get('*').ValueType())
+	should.Equal(jsoniter.InvalidValue, api.Get([]byte(`{"a":1,"a":2}`), "a").ValueType())
+}
+
+func Test_duplicate_keys_first_wins(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{DuplicateKeys: jsoniter.DuplicateKeysFirstWins}.Froze()
+	var obj struct {
+		Field string
+	}
+	should.Nil(api.UnmarshalFromString(`{"field":"a","Field":"b"}`, &obj))
+	should.Equal("a", obj.Field)
+	var m map[string]int
+	should.Nil(api.UnmarshalFromString(`{"a":1,"b":2,"a":3}`, &m))
+	should.Equal(map[string]int{"a": 1, "b": 2}, m)
+	var generic map[string]interface{}
+	should.Nil(api.UnmarshalFromString(`{"a":1,"a":{"b":2}}`, &generic))
+	should.Equal(float64(1), generic["a"])
+	should.True(api.Valid([]byte(`{"a":1,"a":2}`)))
+	should.Equal(1, api.Get([]byte(`{"a":1,"a":2}`), "a").ToInt())
+	any := api.Get([]byte(`{"a":1,"a":2}`))
+	should.Equal(1, any.Get("a").ToInt())
+	should.Equal(1, any.Get('*').Get("a").ToInt())
+	should.Equal(1, any.Size())
+}
+
+func Test_duplicate_keys_last_wins(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{DuplicateKeys: jsoniter.DuplicateKeysLastWins}.Froze()
+	var obj struct {
+		Field string
+	}
+	should.Nil(api.UnmarshalFromString(`{"field":"a","Field":"b"}`, &obj))
+	should.Equal("b", obj.Field)
+	var m map[string]int
+	should.Nil(api.UnmarshalFromString(`{"a":1,"b":2,"a":3}`, &m))
+	should.Equal(map[string]int{"a": 3, "b": 2}, m)
+	should.Equal(2, api.Get([]byte(`{"a":1,"a":2}`), "a").ToInt())
+	any := api.Get([]byte(`{"a":1,"b":[],"a":{"c":2}}`))
+	should.Equal(2, any.Get("a", "c").ToInt())
+	should.Equal(`{"c":2}`, any.Get('*').Get("a").ToString())
+	should.Equal([]string{"a", "b"}, any.Keys())
+}
@@ -0,0 +1,136 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+const editInput = `{
+  "user": { "name": "tom", "ssn": "123-45-6789",  "tags": [ "a", "b" ] },
+  "meta": {"version": 1}
+}`
+
+func Test_set_at_path(t *testing.T) {
+	should := require.New(t)
+	for _, testCase := range []struct {
+		value    interface{}
+		path     []interface{}
+		expected string
+	}{
+		{2, []interface{}{"meta", "version"}, `{
+  "user": { "name": "tom", "ssn": "123-45-6789",  "tags": [ "a", "b" ] },
+  "meta": {"version": 2}
+}`},
+		{map[string]int{"x": 1}, []interface{}{"user", "tags", 1}, `{
+  "user": { "name": "tom", "ssn": "123-45-6789",  "tags": [ "a", {"x":1} ] },
+  "meta": {"version": 1}
+}`},
+		{"<b>", []interface{}{"meta", "author"}, `{
+  "user": { "name": "tom", "ssn": "123-45-6789",  "tags": [ "a", "b" ] },
+  "meta": {"version": 1,"author":"\u003cb\u003e"}
+}`},
+		{nil, []interface{}{}, `null`},
+	} {
+		edited, err := jsoniter.Set([]byte(editInput), testCase.value, testCase.path...)
+		should.Nil(err, testCase.path)
+		should.Equal(testCase.expected, string(edited), testCase.path)
+	}
+	edited, err := jsoniter.Set([]byte(` {} `), true, "a")
+	should.Nil(err)
+	should.Equal(` {"a":true} `, string(edited))
+	edited, err = jsoniter.Set([]byte(` [1] `), 2)
+	should.Nil(err)
+	should.Equal(` 2 `, string(edited))
+}
+
+func Test_set_creating_parents(t *testing.T) {
+	should := require.New(t)
+	edited, err := jsoniter.SetCreatingParents([]byte(`{"a": {}}`), 1, "a", "b", "c", "d")
+	should.Nil(err)
+	should.Equal(`{"a": {"b":{"c":{"d":1}}}}`, string(edited))
+	should.Equal(1, jsoniter.Get(edited, "a", "b", "c", "d").ToInt())
+	edited, err = jsoniter.SetCreatingParents([]byte(`{"a": 1}`), 2, "b", "c")
+	should.Nil(err)
+	should.Equal(`{"a": 1,"b":{"c":2}}`, string(edited))
+	_, err = jsoniter.Set([]byte(`{"a": {}}`), 1, "a", "b", "c")
+	should.NotNil(err)
+	_, err = jsoniter.SetCreatingParents([]byte(`{"a": {}}`), 1, "a", "b", 0)
+	should.NotNil(err)
+}
+
+func Test_delete_at_path(t *testing.T) {
+	should := require.New(t)
+	for _, testCase := range []struct {
+		input    string
+		path     []interface{}
+		expected string
+	}{
+		{editInput, []interface{}{"user", "ssn"}, `{
+  "user": { "name": "tom",  "tags": [ "a", "b" ] },
+  "meta": {"version": 1}
+}`},
+		{editInput, []interface{}{"user", "name"}, `{
+  "user": { "ssn": "123-45-6789",  "tags": [ "a", "b" ] },
+  "meta": {"version": 1}
+}`},
+		{editInput, []interface{}{"user", "tags", 0}, `{
+  "user": { "name": "tom", "ssn": "123-45-6789",  "tags": [ "b" ] },
+  "meta": {"version": 1}
+}`},
+		{editInput, []interface{}{"user", "tags", 1}, `{
+  "user": { "name": "tom", "ssn": "123-45-6789",  "tags": [ "a" ] },
+  "meta": {"version": 1}
+}`},
+		{editInput, []interface{}{"meta"}, `{
+  "user": { "name": "tom", "ssn": "123-45-6789",  "tags": [ "a", "b" ] }
+}`},
+		{`{"a": 1}`, []interface{}{"a"}, `{}`},
+		{`[ [1] ]`, []interface{}{0, 0}, `[ [] ]`},
+		// the duplicate keys are deleted as well, not to be found by Get after
+		{`{"a":1,"a":2}`, []interface{}{"a"}, `{}`},
+		{`{"b":0,"a":1,"c":2,"a":3}`, []interface{}{"a"}, `{"b":0,"c":2}`},
+		{`{"a":1,"a":2,"b":3}`, []interface{}{"a"}, `{"b":3}`},
+	} {
+		edited, err := jsoniter.Delete([]byte(testCase.input), testCase.path...)
+		should.Nil(err, testCase.path)
+		should.Equal(testCase.expected, string(edited), testCase.path)
+	}
+}
+
+func Test_edit_errors(t *testing.T) {
+	should := require.New(t)
+	for _, path := range [][]interface{}{
+		{"user", "missing"}, {"user", "tags", 2}, {"user", "name", "x"}, {"user", 0}, {"meta", "version", 0}, {},
+	} {
+		_, err := jsoniter.Delete([]byte(editInput), path...)
+		should.NotNil(err, path)
+	}
+	for _, path := range [][]interface{}{
+		{"user", "tags", 2}, {"user", "name", "x"}, {"user", 0}, {1.5},
+	} {
+		_, err := jsoniter.Set([]byte(editInput), 1, path...)
+		should.NotNil(err, path)
+	}
+	_, err := jsoniter.Set([]byte(`{"a": [1, }`), 1, "a", 2)
+	should.NotNil(err)
+	// the values after the path are validated as well
+	for _, input := range []string{`{"a":1, "b": [1,2}`, `{"a":1} x`, `{"a":1, "b": }`} {
+		_, err = jsoniter.Set([]byte(input), 7, "a")
+		should.NotNil(err, input)
+		_, err = jsoniter.Delete([]byte(input), "a")
+		should.NotNil(err, input)
+	}
+	_, err = jsoniter.Set([]byte(``), 1)
+	should.NotNil(err)
+	_, err = jsoniter.Set([]byte(`{}`), func() {}, "a")
+	should.NotNil(err)
+	_, err = jsoniter.Config{DuplicateKeys: jsoniter.DuplicateKeysError}.Froze().Set([]byte(`{"a":1,"a":2}`), 3, "a")
+	should.NotNil(err)
+	edited, err := jsoniter.Config{DuplicateKeys: jsoniter.DuplicateKeysLastWins}.Froze().Set([]byte(`{"a":1,"a":2}`), 3, "a")
+	should.Nil(err)
+	should.Equal(`{"a":1,"a":3}`, string(edited))
+	_, err = jsoniter.Config{DuplicateKeys: jsoniter.DuplicateKeysError}.Froze().Delete([]byte(`{"a":1,"a":2}`), "a")
+	should.NotNil(err)
+}
@@ -0,0 +1,116 @@
+package test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+type elementRecord struct {
+	ID int `json:"id"`
+}
+
+func Test_element_decoder_top_level(t *testing.T) {
+	should := require.New(t)
+	input := "[" + strings.Repeat(`{"id":1,"padding":"xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx"},`, 1000) + `{"id":2}]`
+	decoder := jsoniter.NewElementDecoder(jsoniter.ConfigDefault, strings.NewReader(input))
+	count := 0
+	sum := 0
+	for {
+		var record elementRecord
+		err := decoder.Decode(&record)
+		if err == io.EOF {
+			break
+		}
+		should.Nil(err)
+		count++
+		sum += record.ID
+	}
+	should.Equal(1001, count)
+	should.Equal(1002, sum)
+	should.Equal(1000, decoder.Index())
+	should.Equal(io.EOF, decoder.Decode(&elementRecord{}))
+}
+
+func Test_element_decoder_path(t *testing.T) {
+	should := require.New(t)
+	input := `{"meta":{"items":[9]},"data":[{"items":[]},{"skip":[1,{}],"items":[{"id":1},{"id":2}]}]}`
+	decoder := jsoniter.NewElementDecoder(jsoniter.ConfigDefault, strings.NewReader(input), "data", 1, "items")
+	var ids []int
+	for {
+		var record elementRecord
+		err := decoder.Decode(&record)
+		if err == io.EOF {
+			break
+		}
+		should.Nil(err)
+		ids = append(ids, record.ID)
+	}
+	should.Equal([]int{1, 2}, ids)
+	// the empty key is not the end of object
+	for _, testCase := range []struct {
+		input string
+		path  []interface{}
+	}{
+		{`{"":0,"items":[1,2]}`, []interface{}{"items"}},
+		{`{"a":{"":[1,2]}}`, []interface{}{"a", ""}},
+	} {
+		decoder = jsoniter.NewElementDecoder(jsoniter.ConfigDefault, strings.NewReader(testCase.input), testCase.path...)
+		var values []int
+		for {
+			var val int
+			err := decoder.Decode(&val)
+			if err == io.EOF {
+				break
+			}
+			should.Nil(err, testCase.input)
+			values = append(values, val)
+		}
+		should.Equal([]int{1, 2}, values, testCase.input)
+	}
+	decoder = jsoniter.NewElementDecoder(jsoniter.ConfigDefault, strings.NewReader(input), "data", 0, "items")
+	should.Equal(io.EOF, decoder.Decode(&elementRecord{}))
+	decoder = jsoniter.NewElementDecoder(jsoniter.ConfigDefault, strings.NewReader(input), "data", 5)
+	err := decoder.Decode(&elementRecord{})
+	should.NotNil(err)
+	should.Contains(err.Error(), "not found")
+}
+
+func Test_element_decoder_errors(t *testing.T) {
+	should := require.New(t)
+	decoder := jsoniter.NewElementDecoder(jsoniter.ConfigDefault, strings.NewReader(`{"items":[1,2,"x"]}`), "items")
+	var val int
+	should.Nil(decoder.Decode(&val))
+	should.Nil(decoder.Decode(&val))
+	err := decoder.Decode(&val)
+	should.Equal("/items/2", err.(*jsoniter.DecodeError).Pointer)
+	// truncated trailing element
+	decoder = jsoniter.NewElementDecoder(jsoniter.ConfigDefault, strings.NewReader(`[12,34`))
+	should.Nil(decoder.Decode(&val))
+	err = decoder.Decode(&val)
+	should.NotNil(err)
+	should.Contains(err.Error(), "truncated element")
+	should.Equal("/1", err.(*jsoniter.DecodeError).Pointer)
+	decoder = jsoniter.NewElementDecoder(jsoniter.ConfigDefault, strings.NewReader(`[{"id":1},{"id":2`))
+	should.Nil(decoder.Decode(&elementRecord{}))
+	should.NotNil(decoder.Decode(&elementRecord{}))
+	decoder = jsoniter.NewElementDecoder(jsoniter.ConfigDefault, strings.NewReader(`[{"id":1}`))
+	should.Nil(decoder.Decode(&elementRecord{}))
+	should.Contains(decoder.Decode(&elementRecord{}).Error(), "end of input")
+}
+
+func Test_element_decoder_context(t *testing.T) {
+	should := require.New(t)
+	decoder := jsoniter.NewElementDecoder(jsoniter.ConfigDefault, strings.NewReader(`[1,2,3]`))
+	ctx, cancel := context.WithCancel(context.Background())
+	var val int
+	should.Nil(decoder.DecodeContext(ctx, &val))
+	cancel()
+	should.Equal(context.Canceled, decoder.DecodeContext(ctx, &val))
+	should.Nil(decoder.Decode(&val))
+	should.Equal(2, val)
+}
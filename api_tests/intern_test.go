@@ -0,0 +1,59 @@
+package test
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+func stringData(str string) uintptr {
+	return (*reflect.StringHeader)(unsafe.Pointer(&str)).Data
+}
+
+func Test_intern_strings(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{InternStrings: 1024}.Froze()
+	long := strings.Repeat("x", 100)
+	input := `[{"status":"ok","region":"eu-west-1","escaped":"a\tb","long":"` + long + `"},
+		{"status":"ok","region":"eu-west-1","escaped":"a\tb","long":"` + long + `"}]`
+	var val []map[string]string
+	should.Nil(api.UnmarshalFromString(input, &val))
+	should.Equal("ok", val[1]["status"])
+	should.Equal(stringData(val[0]["status"]), stringData(val[1]["status"]))
+	should.Equal(stringData(val[0]["region"]), stringData(val[1]["region"]))
+	should.Equal(stringData(val[0]["escaped"]), stringData(val[1]["escaped"]))
+	should.NotEqual(stringData(val[0]["long"]), stringData(val[1]["long"]))
+	for key := range val[0] {
+		for key2 := range val[1] {
+			if key == key2 {
+				should.Equal(stringData(key), stringData(key2))
+			}
+		}
+	}
+	var generic []interface{}
+	should.Nil(api.UnmarshalFromString(input, &generic))
+	should.Equal(stringData(val[0]["status"]), stringData(generic[0].(map[string]interface{})["status"].(string)))
+}
+
+func Test_intern_strings_concurrently(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{InternStrings: 2}.Froze()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				var val []string
+				should.Nil(api.UnmarshalFromString(`["a","b","c","d"]`, &val))
+				should.Equal([]string{"a", "b", "c", "d"}, val)
+			}
+		}()
+	}
+	wg.Wait()
+}
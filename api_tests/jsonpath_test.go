@@ -0,0 +1,151 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+const bookstore = `{ "store": {
+    "book": [
+      { "category": "reference", "author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95 },
+      { "category": "fiction", "author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99 },
+      { "category": "fiction", "author": "Herman Melville", "title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99 },
+      { "category": "fiction", "author": "J. R. R. Tolkien", "title": "The Lord of the Rings", "isbn": "0-395-19395-8", "price": 22.99 }
+    ],
+    "bicycle": { "color": "red", "price": 399 }
+  } }`
+
+func queryToString(t *testing.T, data string, path string) string {
+	result := jsoniter.Query([]byte(data), path)
+	require.Nil(t, result.LastError(), path)
+	return result.ToString()
+}
+
+func Test_jsonpath_bookstore(t *testing.T) {
+	should := require.New(t)
+	for path, expected := range map[string]string{
+		`$.store.book[*].author`:                    `["Nigel Rees","Evelyn Waugh","Herman Melville","J. R. R. Tolkien"]`,
+		`$..author`:                                 `["Nigel Rees","Evelyn Waugh","Herman Melville","J. R. R. Tolkien"]`,
+		`$.store..price`:                            `[8.95,12.99,8.99,22.99,399]`,
+		`$..book[2].title`:                          `["Moby Dick"]`,
+		`$..book[-1].title`:                         `["The Lord of the Rings"]`,
+		`$..book[0,1].title`:                        `["Sayings of the Century","Sword of Honour"]`,
+		`$..book[:2].title`:                         `["Sayings of the Century","Sword of Honour"]`,
+		`$..book[?@.isbn].title`:                    `["Moby Dick","The Lord of the Rings"]`,
+		`$..book[?@.price<10].title`:                `["Sayings of the Century","Moby Dick"]`,
+		`$..book[?@.price > $.store.bicycle.price]`: `[]`,
+		`$.store.bicycle['color', "price"]`:         `["red",399]`,
+		`$["store"]["bicycle"].color`:               `["red"]`,
+		`$.store.missing`:                           `[]`,
+		`$.store.book[10]`:                          `[]`,
+	} {
+		should.Equal(expected, queryToString(t, bookstore, path), path)
+	}
+	should.Equal(27, jsoniter.Query([]byte(bookstore), `$..*`).Size())
+}
+
+func Test_jsonpath_slice(t *testing.T) {
+	should := require.New(t)
+	data := `["a","b","c","d","e","f","g"]`
+	for path, expected := range map[string]string{
+		`$[1:3]`:     `["b","c"]`,
+		`$[5:]`:      `["f","g"]`,
+		`$[1:5:2]`:   `["b","d"]`,
+		`$[5:1:-2]`:  `["f","d"]`,
+		`$[::-1]`:    `["g","f","e","d","c","b","a"]`,
+		`$[-2:]`:     `["f","g"]`,
+		`$[1:3:0]`:   `[]`,
+		`$[10:20]`:   `[]`,
+		`$[0:2,5]`:   `["a","b","f"]`,
+		`$[ 1 : 2 ]`: `["b"]`,
+	} {
+		should.Equal(expected, queryToString(t, data, path), path)
+	}
+}
+
+func Test_jsonpath_descendant(t *testing.T) {
+	should := require.New(t)
+	data := `{"o": {"j": 1, "k": 2}, "a": [5, 3, [{"j": 4}, {"k": 6}]]}`
+	should.Equal(`[1,4]`, queryToString(t, data, `$..j`))
+	should.Equal(`[5,{"j": 4}]`, queryToString(t, data, `$..[0]`))
+	should.Equal(`[{"j": 1, "k": 2},[5, 3, [{"j": 4}, {"k": 6}]],1,2,5,3,[{"j": 4}, {"k": 6}],{"j": 4},{"k": 6},4,6]`,
+		queryToString(t, data, `$..*`))
+}
+
+func Test_jsonpath_filter(t *testing.T) {
+	should := require.New(t)
+	data := `{"a": [3, 5, 1, 2, 4, 6, {"b": "j"}, {"b": "k"}, {"b": {}}, {"b": "kilo"}], "o": {"p": 1, "q": 2, "r": 3, "s": 5, "t": {"u": 6}}, "e": "f"}`
+	for path, expected := range map[string]string{
+		`$.a[?@.b == 'kilo']`:              `[{"b": "kilo"}]`,
+		`$.a[?(@.b == 'kilo')]`:            `[{"b": "kilo"}]`,
+		`$.a[?@>3.5]`:                      `[5,4,6]`,
+		`$.a[?@.b]`:                        `[{"b": "j"},{"b": "k"},{"b": {}},{"b": "kilo"}]`,
+		`$[?@.*]`:                          `[[3, 5, 1, 2, 4, 6, {"b": "j"}, {"b": "k"}, {"b": {}}, {"b": "kilo"}],{"p": 1, "q": 2, "r": 3, "s": 5, "t": {"u": 6}}]`,
+		`$[?@[?@.b]]`:                      `[[3, 5, 1, 2, 4, 6, {"b": "j"}, {"b": "k"}, {"b": {}}, {"b": "kilo"}]]`,
+		`$.o[?@<3, ?@<3]`:                  `[1,2,1,2]`,
+		`$.a[?@<2 || @.b == "k"]`:          `[1,{"b": "k"}]`,
+		`$.a[?!(@ < 2 || @.b)]`:            `[3,5,2,4,6]`,
+		`$.a[?@ > 1 && @ < 4]`:             `[3,2]`,
+		`$.a[?@.b == $.x]`:                 `[3,5,1,2,4,6]`,
+		`$.a[?@ == @]`:                     `[3,5,1,2,4,6,{"b": "j"},{"b": "k"},{"b": {}},{"b": "kilo"}]`,
+		`$.a[?@.b == {}]`:                  ``,
+		`$[?@ == "f"]`:                     `["f"]`,
+		`$.o[?@.u == 6]`:                   `[{"u": 6}]`,
+		`$.a[?match(@.b, "[jk]")]`:         `[{"b": "j"},{"b": "k"}]`,
+		`$.a[?search(@.b, "[jk]")]`:        `[{"b": "j"},{"b": "k"},{"b": "kilo"}]`,
+		`$.a[?length(@.b) == 4]`:           `[{"b": "kilo"}]`,
+		`$.a[?length(@.b) == 0]`:           `[{"b": {}}]`,
+		`$[?count(@.*) > 5]`:               `[[3, 5, 1, 2, 4, 6, {"b": "j"}, {"b": "k"}, {"b": {}}, {"b": "kilo"}]]`,
+		`$.o[?value(@..u) == 6]`:           `[{"u": 6}]`,
+		`$.a[?@.b == 'k' || @.b == "j"].b`: `["j","k"]`,
+	} {
+		if expected == `` {
+			// comparing with the object literal is not allowed
+			should.NotNil(jsoniter.Query([]byte(data), path).LastError(), path)
+			continue
+		}
+		should.Equal(expected, queryToString(t, data, path), path)
+	}
+}
+
+func Test_jsonpath_compile_errors(t *testing.T) {
+	should := require.New(t)
+	for _, path := range []string{
+		``, `store`, `$.`, `$[`, `$[1`, `$[01]`, `$[-0]`, `$[9007199254740992]`, `$['a`, `$['\q']`,
+		`$[?@.a == ]`, `$[?@.* == 1]`, `$[?@..a == 1]`, `$[?1]`, `$[?length(@)]`, `$[?count(1) == 1]`,
+		`$[?unknown(@)]`, `$[?match(@, "[")]`, `$[?(@.a]`, `$.a b`, `$[?@.a === 1]`,
+	} {
+		_, err := jsoniter.CompileJSONPath(path)
+		should.NotNil(err, path)
+		should.NotNil(jsoniter.Query([]byte(`{}`), path).LastError(), path)
+	}
+	should.Panics(func() { jsoniter.MustCompileJSONPath(`$[`) })
+}
+
+func Test_jsonpath_compiled_and_any(t *testing.T) {
+	should := require.New(t)
+	path := jsoniter.MustCompileJSONPath(`$..book[?@.price < 10]["title"]`)
+	should.Equal(`$..book[?@.price < 10]["title"]`, path.String())
+	root := jsoniter.Get([]byte(bookstore))
+	should.Equal([]string{"Sayings of the Century", "Moby Dick"}, []string{
+		path.Query(root).Get(0).ToString(), path.Query(root).Get(1).ToString(),
+	})
+	// Any of go values is queried as well
+	wrapped := jsoniter.Wrap(map[string]interface{}{"a": []interface{}{1, "x", map[string]interface{}{"b": true}}})
+	should.Equal(`["x"]`, wrapped.Query(`$.a[1]`).ToString())
+	should.Equal(true, wrapped.Query(`$..b`).Get(0).ToBool())
+	should.Equal(2, root.Get("store", "book").Query(`$[?@.isbn]`).Size())
+	should.Equal(`["中"]`, queryToString(t, `{"中":"中"}`, `$['中']`))
+	should.Equal(`["x"]`, queryToString(t, `{"a b":"x"}`, `$ [ 'a b' ]`))
+	invalid := jsoniter.Get([]byte(`{`), "a")
+	should.Equal(jsoniter.InvalidValue, invalid.Query(`$`).ValueType())
+}
+
+func Test_jsonpath_lazy(t *testing.T) {
+	should := require.New(t)
+	// the subtree not visited is not parsed, even it is invalid
+	result := jsoniter.Query([]byte(`{"a": {"b": 1}, "c": [1, 2, }`), `$.a.b`)
+	should.Equal(`[1]`, result.ToString())
+}
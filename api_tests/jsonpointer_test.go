@@ -0,0 +1,61 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_get_pointer(t *testing.T) {
+	should := require.New(t)
+	// the examples of RFC 6901 section 5
+	data := []byte(`{"foo": ["bar", "baz"], "": 0, "a/b": 1, "c%d": 2, "e^f": 3, "g|h": 4, "i\\j": 5,
+		"k\"l": 6, " ": 7, "m~n": 8, "0": {"1": "one"}, "items": [{"name": "x"}, {"name": "y"}]}`)
+	for pointer, expected := range map[string]string{
+		``:              string(data),
+		`/foo`:          `["bar", "baz"]`,
+		`/foo/0`:        `bar`,
+		`/`:             `0`,
+		`/a~1b`:         `1`,
+		`/c%d`:          `2`,
+		`/e^f`:          `3`,
+		`/g|h`:          `4`,
+		`/i\j`:          `5`,
+		`/k"l`:          `6`,
+		`/ `:            `7`,
+		`/m~0n`:         `8`,
+		`/0/1`:          `one`,
+		`/items/1/name`: `y`,
+	} {
+		found := jsoniter.GetPointer(data, pointer)
+		should.Nil(found.LastError(), pointer)
+		should.Equal(expected, found.ToString(), pointer)
+	}
+	should.Equal("y", jsoniter.Get(data, "items").GetPointer("/1/name").ToString())
+	wrapped := jsoniter.Wrap(map[string]interface{}{"a": []interface{}{1, map[string]interface{}{"b": true}}})
+	should.Equal(true, wrapped.GetPointer("/a/1/b").ToBool())
+}
+
+func Test_get_pointer_errors(t *testing.T) {
+	should := require.New(t)
+	data := []byte(`{"a": {"b": [1, 2, 3], "c": "x"}}`)
+	for pointer, expected := range map[string]jsoniter.PointerError{
+		`a`:       {Pointer: `a`, Message: "must be empty or start with /"},
+		`/x`:      {Pointer: `/x`, Segment: 0, Token: "x", At: "", Message: "key not found in object"},
+		`/a/b/3`:  {Pointer: `/a/b/3`, Segment: 2, Token: "3", At: "/a/b", Message: "index out of range of array of size 3"},
+		`/a/b/-`:  {Pointer: `/a/b/-`, Segment: 2, Token: "-", At: "/a/b", Message: "- refers to the nonexistent element after the last one"},
+		`/a/b/01`: {Pointer: `/a/b/01`, Segment: 2, Token: "01", At: "/a/b", Message: "invalid array index"},
+		`/a/b/x`:  {Pointer: `/a/b/x`, Segment: 2, Token: "x", At: "/a/b", Message: "invalid array index"},
+		`/a/c/0`:  {Pointer: `/a/c/0`, Segment: 2, Token: "0", At: "/a/c", Message: "string value has no children"},
+		`/a/~2`:   {Pointer: `/a/~2`, Segment: 1, Token: "~2", At: "/a", Message: "invalid ~ escape"},
+	} {
+		found := jsoniter.GetPointer(data, pointer)
+		should.Equal(jsoniter.InvalidValue, found.ValueType(), pointer)
+		var pointerErr *jsoniter.PointerError
+		should.True(errors.As(found.LastError(), &pointerErr), pointer)
+		should.Equal(expected, *pointerErr)
+	}
+	should.Contains(jsoniter.GetPointer(data, "/a/b/3").LastError().Error(), `token 2 "3" at "/a/b"`)
+}
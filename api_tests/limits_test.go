@@ -0,0 +1,149 @@
+package test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+func limitOf(err error) string {
+	decodeErr, ok := err.(*jsoniter.DecodeError)
+	if !ok {
+		return ""
+	}
+	limitErr, ok := decodeErr.Err.(*jsoniter.LimitError)
+	if !ok {
+		return ""
+	}
+	return limitErr.Limit
+}
+
+func Test_max_depth(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{MaxDepth: 3}.Froze()
+	type Node struct {
+		Next *Node `json:"next"`
+	}
+	var node Node
+	should.Nil(api.UnmarshalFromString(`{"next":{"next":{}}}`, &node))
+	err := api.UnmarshalFromString(`{"next":{"next":{"next":{}}}}`, &node)
+	should.Equal("MaxDepth", limitOf(err))
+	should.Contains(err.Error(), "MaxDepth of 3 exceeded")
+	var val interface{}
+	should.Nil(api.UnmarshalFromString(`[[{"a":1}]]`, &val))
+	should.Equal("MaxDepth", limitOf(api.UnmarshalFromString(`[[{"a":[]}]]`, &val)))
+	var slice [][][][]int
+	should.Equal("MaxDepth", limitOf(api.UnmarshalFromString(`[[[[1]]]]`, &slice)))
+	var m map[string]map[string]map[string]map[string]int
+	should.Equal("MaxDepth", limitOf(api.UnmarshalFromString(`{"a":{"b":{"c":{"d":1}}}}`, &m)))
+	// unknown fields are skipped with the same limit
+	should.Equal("MaxDepth", limitOf(api.UnmarshalFromString(`{"other":[[[1]]]}`, &node)))
+	should.False(api.Valid([]byte(`[[[[1]]]]`)))
+	should.True(api.Valid([]byte(`[[[1]]]`)))
+	// the depth is reset for the next value
+	decoder := api.NewDecoder(bytes.NewBufferString(`[[[1]]] [[[2]]]`))
+	should.Nil(decoder.Decode(&val))
+	should.Nil(decoder.Decode(&val))
+}
+
+func Test_max_depth_does_not_overflow_stack(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{MaxDepth: 1000}.Froze()
+	input := strings.Repeat("[", 1000000)
+	var val interface{}
+	should.Equal("MaxDepth", limitOf(api.UnmarshalFromString(input, &val)))
+	should.Equal(jsoniter.InvalidValue, api.Get([]byte(input), 0).ValueType())
+}
+
+func Test_max_string_length(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{MaxStringLength: 4}.Froze()
+	var str string
+	should.Nil(api.UnmarshalFromString(`"abcd"`, &str))
+	should.Equal("MaxStringLength", limitOf(api.UnmarshalFromString(`"abcde"`, &str)))
+	should.Equal("MaxStringLength", limitOf(api.UnmarshalFromString(`"ab\ncde"`, &str)))
+	should.Nil(api.UnmarshalFromString(`"中"`, &str))
+	type Obj struct {
+		Name string
+	}
+	var obj Obj
+	should.Equal("MaxStringLength", limitOf(api.UnmarshalFromString(`{"Other":"abcdef"}`, &obj)))
+	iter := jsoniter.Parse(api, bytes.NewBufferString(`"abcdefgh"`), 2)
+	iter.ReadString()
+	should.Equal("MaxStringLength", limitOf(iter.Error))
+}
+
+func Test_max_array_elements(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{MaxArrayElements: 3}.Froze()
+	var slice []int
+	should.Nil(api.UnmarshalFromString(`[1,2,3]`, &slice))
+	should.Equal("MaxArrayElements", limitOf(api.UnmarshalFromString(`[1,2,3,4]`, &slice)))
+	var array [2]int
+	should.Equal("MaxArrayElements", limitOf(api.UnmarshalFromString(`[1,2,3,4]`, &array)))
+	var val interface{}
+	should.Equal("MaxArrayElements", limitOf(api.UnmarshalFromString(`[1,2,3,4]`, &val)))
+	should.False(api.Valid([]byte(`[1,2,3,4]`)))
+}
+
+func Test_max_object_members(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{MaxObjectMembers: 2}.Froze()
+	var m map[string]int
+	should.Nil(api.UnmarshalFromString(`{"a":1,"b":2}`, &m))
+	should.Equal("MaxObjectMembers", limitOf(api.UnmarshalFromString(`{"a":1,"b":2,"c":3}`, &m)))
+	var val interface{}
+	should.Equal("MaxObjectMembers", limitOf(api.UnmarshalFromString(`{"a":1,"b":2,"c":3}`, &val)))
+	iter := jsoniter.ParseString(api, `{"a":1,"b":2,"c":3}`)
+	iter.ReadMapCB(func(iter *jsoniter.Iterator, field string) bool {
+		iter.Skip()
+		return true
+	})
+	should.Equal("MaxObjectMembers", limitOf(iter.Error))
+	type OneField struct {
+		A int `json:"a"`
+	}
+	var oneField OneField
+	should.Equal("MaxObjectMembers", limitOf(api.UnmarshalFromString(`{"a":1,"b":2,"c":3}`, &oneField)))
+	type ManyFields struct {
+		A, B, C, D, E, F, G, H, I, J, K int
+	}
+	var manyFields ManyFields
+	should.Nil(api.UnmarshalFromString(`{"A":1,"B":2}`, &manyFields))
+	should.Equal("MaxObjectMembers", limitOf(api.UnmarshalFromString(`{"A":1,"B":2,"C":3}`, &manyFields)))
+	data := []byte(`{"a":1,"b":2,"c":3}`)
+	should.Equal("MaxObjectMembers", limitOf(api.Get(data, "c").LastError()))
+	should.Equal("MaxObjectMembers", limitOf(api.Get(data).Get("c").LastError()))
+}
+
+func Test_max_input_bytes(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{MaxInputBytes: 8}.Froze()
+	var slice []int
+	should.Nil(api.UnmarshalFromString(`[1,2,3]`, &slice))
+	should.Equal("MaxInputBytes", limitOf(api.UnmarshalFromString(`[1,2,3,4,5]`, &slice)))
+	decoder := api.NewDecoder(bytes.NewBufferString(`[1,2,3,4,5]`))
+	should.Equal("MaxInputBytes", limitOf(decoder.Decode(&slice)))
+	// the input read ahead into the buffer is not counted
+	decoder = jsoniter.Config{MaxInputBytes: 10}.Froze().NewDecoder(bytes.NewBufferString(`1 2 3 4 5 6 7 8`))
+	var numbers []int
+	for {
+		var number int
+		if err := decoder.Decode(&number); err != nil {
+			should.Equal("MaxInputBytes", limitOf(err))
+			break
+		}
+		numbers = append(numbers, number)
+	}
+	should.Equal([]int{1, 2, 3, 4, 5}, numbers)
+}
+
+func Test_limit_error_is_not_converted_to_standard_library_error(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{StandardLibraryErrors: true, MaxArrayElements: 1}.Froze()
+	var slice []int
+	should.Equal("MaxArrayElements", limitOf(api.UnmarshalFromString(`[1,2]`, &slice)))
+}
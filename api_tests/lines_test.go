@@ -0,0 +1,90 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+type lineRecord struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func Test_line_decoder(t *testing.T) {
+	should := require.New(t)
+	input := "{\"id\":1,\"name\":\"a\"}\r\n\n  \n{\"id\":\"x\"}\n{\"id\":3,\"name\":\"c\"}"
+	decoder := jsoniter.NewLineDecoder(jsoniter.ConfigDefault, strings.NewReader(input))
+	var record lineRecord
+	should.Nil(decoder.Decode(&record))
+	should.Equal(lineRecord{1, "a"}, record)
+	should.Equal(1, decoder.Line())
+	err := decoder.Decode(&record)
+	lineErr, ok := err.(*jsoniter.LineError)
+	should.True(ok)
+	should.Equal(4, lineErr.Line)
+	should.Contains(err.Error(), "line 4: ")
+	_, ok = lineErr.Err.(*jsoniter.DecodeError)
+	should.True(ok)
+	record = lineRecord{}
+	should.Nil(decoder.Decode(&record))
+	should.Equal(lineRecord{3, "c"}, record)
+	should.Equal(io.EOF, decoder.Decode(&record))
+}
+
+func Test_line_decoder_skip_malformed(t *testing.T) {
+	should := require.New(t)
+	input := "[1]\n[2,\n\"x\"\n[3] [4]\n" + "[" + strings.Repeat("5,", 5000) + "5]\n[6]\n"
+	decoder := jsoniter.NewLineDecoder(jsoniter.ConfigDefault, strings.NewReader(input))
+	decoder.SkipMalformed()
+	decoder.MaxLineSize(100)
+	var records [][]int
+	for {
+		var record []int
+		err := decoder.Decode(&record)
+		if err == io.EOF {
+			break
+		}
+		should.Nil(err)
+		records = append(records, record)
+	}
+	should.Equal([][]int{{1}, {6}}, records)
+	lines := []int{}
+	for _, skipped := range decoder.Skipped() {
+		lines = append(lines, skipped.Line)
+	}
+	should.Equal([]int{2, 3, 4, 5}, lines)
+	limitErr, ok := decoder.Skipped()[3].Err.(*jsoniter.LimitError)
+	should.True(ok)
+	should.Equal("MaxLineSize", limitErr.Limit)
+}
+
+type multiLineMarshaler struct{}
+
+func (multiLineMarshaler) MarshalJSON() ([]byte, error) {
+	return []byte("{\n  \"a b\": [1,\n 2]\n}"), nil
+}
+
+func Test_line_encoder(t *testing.T) {
+	should := require.New(t)
+	var buf bytes.Buffer
+	api := jsoniter.Config{IndentionStep: 2}.Froze()
+	encoder := jsoniter.NewLineEncoder(api, &buf)
+	should.Nil(encoder.Encode(lineRecord{1, "line\nbreak"}))
+	should.Nil(encoder.Encode(multiLineMarshaler{}))
+	should.Nil(encoder.Encode(json.RawMessage("[1,\r\n2]")))
+	should.Nil(encoder.Encode(map[string]int{"x": 1}))
+	should.Equal(`{"id":1,"name":"line\nbreak"}`+"\n"+
+		`{"a b":[1,2]}`+"\n"+
+		`[1,2]`+"\n"+
+		`{"x":1}`+"\n", buf.String())
+	decoder := jsoniter.NewLineDecoder(api, &buf)
+	var record lineRecord
+	should.Nil(decoder.Decode(&record))
+	should.Equal("line\nbreak", record.Name)
+}
@@ -0,0 +1,84 @@
+package test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+type parallelRecord struct {
+	ID    int               `json:"id"`
+	Name  string            `json:"name"`
+	Tags  []string          `json:"tags"`
+	Attrs map[string]string `json:"attrs"`
+	Next  *parallelRecord   `json:"next"`
+}
+
+func parallelInput(count int, broken int) []byte {
+	records := make([]string, count)
+	for i := range records {
+		id := strconv.Itoa(i)
+		if i == broken {
+			id = `"` + id + `"`
+		}
+		records[i] = `{"id":` + id + `,"name":"n` + strconv.Itoa(i) + `","tags":["a","b"],` +
+			`"attrs":{"k":"v` + strconv.Itoa(i) + `"},"next":{"id":` + strconv.Itoa(i*2) + `}}`
+	}
+	return []byte("[" + strings.Join(records, ",\n") + "]")
+}
+
+func Test_parallel_decode_same_as_sequential(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{ParallelDecodeWorkers: 4, ParallelDecodeMinBytes: 1}.Froze()
+	input := parallelInput(1000, -1)
+	var expected []parallelRecord
+	should.Nil(jsoniter.Unmarshal(input, &expected))
+	var actual []parallelRecord
+	should.Nil(api.Unmarshal(input, &actual))
+	should.Equal(expected, actual)
+	// decoding into existing slice
+	existing := make([]parallelRecord, 3, 2000)
+	existing[1].Name = "kept when not in input"
+	expected = make([]parallelRecord, 3, 2000)
+	expected[1].Name = "kept when not in input"
+	should.Nil(jsoniter.Unmarshal(input, &expected))
+	should.Nil(api.Unmarshal(input, &existing))
+	should.Equal(expected, existing)
+	// nested in object
+	type Wrapper struct {
+		Items [][]int
+	}
+	var wrapped Wrapper
+	should.Nil(api.UnmarshalFromString(`{"Items":[[1,2],[],null,[3]]}`, &wrapped))
+	should.Equal([][]int{{1, 2}, {}, nil, {3}}, wrapped.Items)
+	// the arrays too small are decoded sequentially, next to the one large enough
+	api = jsoniter.Config{ParallelDecodeWorkers: 4, ParallelDecodeMinBytes: 1 << 10}.Froze()
+	type Mixed struct {
+		Small   [][]int
+		Records []parallelRecord
+	}
+	input = []byte(`{"Small":[[1,2],[3],[]],"Records":` + string(parallelInput(100, -1)) + `}`)
+	var expectedMixed, actualMixed Mixed
+	should.Nil(jsoniter.Unmarshal(input, &expectedMixed))
+	should.Nil(api.Unmarshal(input, &actualMixed))
+	should.Equal(expectedMixed, actualMixed)
+}
+
+func Test_parallel_decode_error(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{ParallelDecodeWorkers: 4, ParallelDecodeMinBytes: 1}.Froze()
+	input := parallelInput(1000, 777)
+	var expected []parallelRecord
+	expectedErr := jsoniter.Unmarshal(input, &expected)
+	var actual []parallelRecord
+	err := api.Unmarshal(input, &actual)
+	should.NotNil(err)
+	should.Equal("/777/id", err.(*jsoniter.DecodeError).Pointer)
+	should.Equal(expectedErr.Error(), err.Error())
+	should.Equal(expected, actual)
+	// malformed input
+	should.NotNil(api.UnmarshalFromString(`[1,2,3,4,5,6,7,8,}`, &[]int{}))
+}
@@ -0,0 +1,177 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+// pushEvents feeds the input split at the given positions, and returns the events as strings
+func pushEvents(input string, splits ...int) ([]string, error) {
+	parser := jsoniter.NewPushParser(jsoniter.ConfigDefault)
+	var events []string
+	parser.OnEvent(func(event jsoniter.PushEvent, value string) error {
+		events = append(events, fmt.Sprintf("%d:%s", event, value))
+		return nil
+	})
+	start := 0
+	for _, split := range append(splits, len(input)) {
+		if _, err := parser.Write([]byte(input[start:split])); err != nil {
+			return events, err
+		}
+		start = split
+	}
+	return events, parser.Close()
+}
+
+func Test_push_parser_events(t *testing.T) {
+	should := require.New(t)
+	input := ` {"a\"b":[1.5e3,-0,true,false,null,"é😀\n"],"":{},"c":[[]]} 12 "x"`
+	expected, err := pushEvents(input)
+	should.Nil(err)
+	should.Equal([]string{"0:", "4:a\"b", "2:", "6:1.5e3", "6:-0", "7:true", "7:false", "8:", "5:é😀\n", "3:",
+		"4:", "0:", "1:", "4:c", "2:", "2:", "3:", "3:", "1:", "6:12", "5:x"}, expected)
+	for i := 0; i <= len(input); i++ {
+		for j := i; j <= len(input); j++ {
+			events, err := pushEvents(input, i, j)
+			should.Nil(err, "split at %d and %d", i, j)
+			should.Equal(expected, events, "split at %d and %d", i, j)
+		}
+	}
+}
+
+func Test_push_parser_values(t *testing.T) {
+	should := require.New(t)
+	type Message struct {
+		ID   int      `json:"id"`
+		Tags []string `json:"tags"`
+	}
+	input := `{"id":1,"tags":["a","b\"c"]}` + "\n" + `{"id":2,"tags":[]}{"id":3}`
+	parser := jsoniter.NewPushParser(jsoniter.ConfigDefault)
+	var messages []Message
+	parser.OnValue(func(iter *jsoniter.Iterator) error {
+		var message Message
+		iter.ReadVal(&message)
+		messages = append(messages, message)
+		return nil
+	})
+	for i := 0; i < len(input); i++ {
+		n, err := parser.Write([]byte{input[i]})
+		should.Nil(err)
+		should.Equal(1, n)
+	}
+	should.Nil(parser.Close())
+	should.Equal([]Message{{1, []string{"a", `b"c`}}, {2, []string{}}, {3, nil}}, messages)
+
+	var numbers []float64
+	parser = jsoniter.NewPushParser(jsoniter.ConfigDefault)
+	parser.OnValue(func(iter *jsoniter.Iterator) error {
+		numbers = append(numbers, iter.ReadFloat64())
+		return nil
+	})
+	_, err := parser.Write([]byte("1 2.5 -3"))
+	should.Nil(err)
+	should.Equal([]float64{1, 2.5}, numbers)
+	_, err = parser.Write([]byte("e2"))
+	should.Nil(err)
+	should.Nil(parser.Close())
+	should.Equal([]float64{1, 2.5, -300}, numbers)
+
+	// the strings sharing the input are kept valid while the chunk and the carried bytes are reused
+	var strs []string
+	parser = jsoniter.NewPushParser(jsoniter.Config{ZeroCopyStrings: true}.Froze())
+	parser.OnValue(func(iter *jsoniter.Iterator) error {
+		strs = append(strs, iter.ReadString())
+		return nil
+	})
+	chunk := make([]byte, 3)
+	for _, part := range []string{`"a`, `b"`, `"c`, `d"`, `"e"`} {
+		copy(chunk, part)
+		_, err = parser.Write(chunk[:len(part)])
+		should.Nil(err)
+	}
+	should.Nil(parser.Close())
+	should.Equal([]string{"ab", "cd", "e"}, strs)
+}
+
+func Test_push_parser_errors(t *testing.T) {
+	should := require.New(t)
+	for _, input := range []string{`{"a":}`, `[1,]`, `{"a":1,}`, `{"a" 1}`, `[1 2]`, `tru`, `trux`, `01`, `1.`,
+		`{"a":1`, `"abc`, `"\`, `]`, `{]`, `"\x"`, `"\u12"`, `"\u12x4"`, "\"a\tb\"", `{1:2}`} {
+		for split := 0; split <= len(input); split++ {
+			_, err := pushEvents(input, split)
+			should.NotNil(err, input)
+		}
+		// the syntax is validated without the handlers
+		parser := jsoniter.NewPushParser(jsoniter.ConfigDefault)
+		_, err := parser.Write([]byte(input))
+		if err == nil {
+			err = parser.Close()
+		}
+		should.NotNil(err, input)
+	}
+	_, err := pushEvents("[1,\n  x]")
+	var decodeErr *jsoniter.DecodeError
+	should.True(errors.As(err, &decodeErr))
+	should.Equal(int64(6), decodeErr.Offset)
+	should.Equal(2, decodeErr.Line)
+	should.Equal(3, decodeErr.Column)
+
+	parser := jsoniter.NewPushParser(jsoniter.Config{MaxDepth: 2}.Froze())
+	_, err = parser.Write([]byte(`[[[`))
+	should.NotNil(err)
+	_, again := parser.Write([]byte(`1`))
+	should.Equal(err, again)
+	parser.Reset()
+	_, err = parser.Write([]byte(`[[1]]`))
+	should.Nil(err)
+
+	// the carried token and the input fed are limited the same way as Iterator
+	for _, limited := range []struct {
+		config jsoniter.Config
+		chunks []string
+		limit  string
+	}{
+		{jsoniter.Config{MaxStringLength: 5}, []string{`["abc`, `defg"]`}, "MaxStringLength"},
+		{jsoniter.Config{MaxStringLength: 5}, []string{`["abcdefg"]`}, "MaxStringLength"},
+		{jsoniter.Config{MaxStringLength: 5}, []string{`[123`, `4567`, `]`}, "MaxStringLength"},
+		{jsoniter.Config{MaxInputBytes: 8}, []string{`[1,2,`, `3,4,5]`}, "MaxInputBytes"},
+	} {
+		parser = jsoniter.NewPushParser(limited.config.Froze())
+		parser.OnEvent(func(jsoniter.PushEvent, string) error { return nil })
+		for _, chunk := range limited.chunks {
+			if _, err = parser.Write([]byte(chunk)); err != nil {
+				break
+			}
+		}
+		var limitErr *jsoniter.LimitError
+		should.True(errors.As(err, &limitErr), limited.chunks)
+		should.Equal(limited.limit, limitErr.Limit)
+	}
+	parser = jsoniter.NewPushParser(jsoniter.Config{MaxInputBytes: 8}.Froze())
+	n, err := parser.Write([]byte(`[1,2,3,4,5]`))
+	should.Equal(8, n)
+	should.True(errors.As(err, &decodeErr))
+	should.Equal(int64(8), decodeErr.Offset)
+	parser = jsoniter.NewPushParser(jsoniter.Config{MaxStringLength: 5, MaxInputBytes: 9}.Froze())
+	_, err = parser.Write([]byte(`["ab`))
+	should.Nil(err)
+	_, err = parser.Write([]byte(`cde"]`))
+	should.Nil(err)
+
+	stop := errors.New("stop")
+	parser = jsoniter.NewPushParser(jsoniter.ConfigDefault)
+	parser.OnValue(func(iter *jsoniter.Iterator) error {
+		if iter.ReadInt() == 2 {
+			return stop
+		}
+		return nil
+	})
+	n, err = parser.Write([]byte(strings.Repeat("1 2 3 ", 2)))
+	should.Equal(stop, err)
+	should.Equal(3, n)
+}
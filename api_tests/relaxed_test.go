@@ -0,0 +1,139 @@
+package test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+const relaxedInput = `// service config
+{
+	name: 'api',      /* unquoted key, single-quoted string */
+	"port": 8080,
+	'tags': ['a', "b", 'it\'s',],
+	limits: {max_conn: 10, $burst: 20,},
+	/* trailing comma */
+}
+`
+
+func Test_relaxed_unmarshal_struct(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{Relaxed: true}.Froze()
+	type Config struct {
+		Name   string         `json:"name"`
+		Port   int            `json:"port"`
+		Tags   []string       `json:"tags"`
+		Limits map[string]int `json:"limits"`
+	}
+	var cfg Config
+	should.Nil(api.UnmarshalFromString(relaxedInput, &cfg))
+	should.Equal(Config{
+		Name:   "api",
+		Port:   8080,
+		Tags:   []string{"a", "b", "it's"},
+		Limits: map[string]int{"max_conn": 10, "$burst": 20},
+	}, cfg)
+	// many fields use generalStructDecoder
+	type Wide struct {
+		A, B, C, D, E, F, G, H, I, J, K int
+	}
+	var wide Wide
+	should.Nil(api.UnmarshalFromString(`{a:1, 'B':2, /* c */ "C":3, K: 11,}`, &wide))
+	should.Equal(Wide{A: 1, B: 2, C: 3, K: 11}, wide)
+}
+
+func Test_relaxed_unmarshal_interface(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{Relaxed: true}.Froze()
+	var val interface{}
+	should.Nil(api.UnmarshalFromString(relaxedInput, &val))
+	should.Equal(map[string]interface{}{
+		"name":   "api",
+		"port":   float64(8080),
+		"tags":   []interface{}{"a", "b", "it's"},
+		"limits": map[string]interface{}{"max_conn": float64(10), "$burst": float64(20)},
+	}, val)
+	var ints map[int]string
+	should.Nil(api.UnmarshalFromString(`{'1': 'a', "2": "b"}`, &ints))
+	should.Equal(map[int]string{1: "a", 2: "b"}, ints)
+}
+
+func Test_relaxed_get_and_any(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{Relaxed: true}.Froze()
+	should.Equal("it's", api.Get([]byte(relaxedInput), "tags", 2).ToString())
+	should.Equal(20, api.Get([]byte(relaxedInput), "limits", "$burst").ToInt())
+	any := api.Get([]byte(relaxedInput))
+	should.Equal([]string{"limits", "name", "port", "tags"}, sortedKeys(any.Keys()))
+	should.Equal(3, any.Get("tags").Size())
+	should.Equal("api", any.Get("name").ToString())
+	should.True(api.Valid([]byte(relaxedInput)))
+}
+
+func Test_relaxed_iterator(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{Relaxed: true}.Froze()
+	iter := jsoniter.Parse(api, bytes.NewBufferString(relaxedInput), 4)
+	fields := []string{}
+	for field := iter.ReadObject(); field != ""; field = iter.ReadObject() {
+		fields = append(fields, field)
+		iter.Skip()
+	}
+	should.Nil(iter.Error)
+	should.Equal([]string{"name", "port", "tags", "limits"}, fields)
+	iter = jsoniter.ParseString(api, `[1, /**/ 2 // two
+	, 3,]`)
+	elements := []int{}
+	for iter.ReadArray() {
+		elements = append(elements, iter.ReadInt())
+	}
+	should.Equal([]int{1, 2, 3}, elements)
+}
+
+func Test_relaxed_errors(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{Relaxed: true}.Froze()
+	var val interface{}
+	should.NotNil(api.UnmarshalFromString(`[1, /* not closed`, &val))
+	should.NotNil(api.UnmarshalFromString(`[1 / 2]`, &val))
+	should.NotNil(api.UnmarshalFromString(`[1,,]`, &val))
+	should.NotNil(api.UnmarshalFromString(`{'a: 1}`, &val))
+	// the leading or lone comma is not dropped
+	for _, input := range []string{`[,]`, `{,}`, `[ /* */ , ]`, `{a: 1,,}`, `[[],,]`} {
+		should.NotNil(api.UnmarshalFromString(input, &val), input)
+	}
+	should.Nil(api.UnmarshalFromString(`[[], {},]`, &val))
+	// only the object keys may be unquoted
+	type Name struct {
+		A string
+	}
+	var name Name
+	for _, input := range []string{`{"A": true}`, `{"A": foo}`, `{A: foo}`} {
+		should.NotNil(api.UnmarshalFromString(input, &name), input)
+	}
+	should.NotNil(api.UnmarshalFromString(`[foo]`, &val))
+	var strs []string
+	should.NotNil(api.UnmarshalFromString(`[foo]`, &strs))
+	should.Nil(api.UnmarshalFromString(`{A: null}`, &name))
+	should.Nil(api.UnmarshalFromString(`{null: 'x'}`, &val))
+	should.Equal(map[string]interface{}{"null": "x"}, val)
+	// strict mode is unchanged
+	should.NotNil(jsoniter.UnmarshalFromString(`[1,]`, &val))
+	should.NotNil(jsoniter.UnmarshalFromString(`{a:1}`, &val))
+	should.NotNil(jsoniter.UnmarshalFromString(`['a']`, &val))
+	should.NotNil(jsoniter.UnmarshalFromString(`[1] // comment`, &val))
+	should.False(jsoniter.Valid([]byte(relaxedInput)))
+}
+
+func sortedKeys(keys []string) []string {
+	for i := range keys {
+		for j := i + 1; j < len(keys); j++ {
+			if keys[j] < keys[i] {
+				keys[i], keys[j] = keys[j], keys[i]
+			}
+		}
+	}
+	return keys
+}
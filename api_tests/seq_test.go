@@ -0,0 +1,53 @@
+package test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sequence_decoder(t *testing.T) {
+	should := require.New(t)
+	input := "\x1e{\"a\":1}\n\x1e\x1e[1,2\n\x1e123\x1e\"str\"\n\x1e456\n\x1e{\"a\":\n2}\n"
+	decoder := jsoniter.NewSequenceDecoder(jsoniter.ConfigDefault, strings.NewReader(input))
+	var val interface{}
+	should.Nil(decoder.Decode(&val))
+	should.Equal(map[string]interface{}{"a": float64(1)}, val)
+	// corrupted record, resync at next separator
+	err := decoder.Decode(&val)
+	seqErr, ok := err.(*jsoniter.SequenceError)
+	should.True(ok)
+	should.Equal(2, seqErr.Record)
+	// truncated number
+	err = decoder.Decode(&val)
+	should.Equal(jsoniter.ErrTruncatedRecord, err.(*jsoniter.SequenceError).Err)
+	should.Nil(decoder.Decode(&val))
+	should.Equal("str", val)
+	should.Nil(decoder.Decode(&val))
+	should.Equal(float64(456), val)
+	should.Nil(decoder.Decode(&val))
+	should.Equal(map[string]interface{}{"a": float64(2)}, val)
+	should.Equal(io.EOF, decoder.Decode(&val))
+}
+
+func Test_sequence_encoder(t *testing.T) {
+	should := require.New(t)
+	var buf bytes.Buffer
+	encoder := jsoniter.NewSequenceEncoder(jsoniter.ConfigDefault, &buf)
+	should.Nil(encoder.Encode(map[string]int{"a": 1}))
+	should.Nil(encoder.Encode(1))
+	should.NotNil(encoder.Encode(func() {}))
+	should.Nil(encoder.Encode("x"))
+	should.Equal("\x1e{\"a\":1}\n\x1e1\n\x1e\"x\"\n", buf.String())
+	decoder := jsoniter.NewSequenceDecoder(jsoniter.ConfigDefault, &buf)
+	var val interface{}
+	should.Nil(decoder.Decode(&val))
+	should.Nil(decoder.Decode(&val))
+	should.Equal(float64(1), val)
+	should.Nil(decoder.Decode(&val))
+	should.Equal("x", val)
+}
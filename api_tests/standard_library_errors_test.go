@@ -0,0 +1,186 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_standard_library_syntax_error(t *testing.T) {
+	should := require.New(t)
+	for _, input := range []string{`{"a":}`, `[1,2`, `{"a":1} x`, ``, `{"a" 1}`,
+		`{"a":1,}`, `{"a":1,2:3}`, `{1:2}`, `{"a":1 "b":2}`, `{"a":[1,2}`, `{"a":"x`, `{"a`, `  `} {
+		var obj1, obj2 map[string]interface{}
+		err1 := json.Unmarshal([]byte(input), &obj1)
+		err2 := jsoniter.ConfigCompatibleWithStandardLibrary.Unmarshal([]byte(input), &obj2)
+		syntaxErr, ok := err2.(*json.SyntaxError)
+		should.True(ok, input)
+		should.Equal(err1.(*json.SyntaxError).Offset, syntaxErr.Offset, input)
+		should.Equal(err1.Error(), syntaxErr.Error(), input)
+	}
+}
+
+func Test_standard_library_unmarshal_type_error(t *testing.T) {
+	should := require.New(t)
+	type Inner struct {
+		Count int `json:"count"`
+	}
+	type Outer struct {
+		Name  string  `json:"name"`
+		Inner Inner   `json:"inner"`
+		List  []Inner `json:"list"`
+	}
+	for _, input := range []string{
+		`{"name": 1}`,
+		`{"inner": {"count": "5"}}`,
+		`{"inner": {"count": 1.5}}`,
+		`{"list": [{"count": 1}, {"count": true}]}`,
+		`{"list": [{"count": 1}, "x"]}`,
+		`{"inner": []}`,
+		`{"inner": {"count": {"a": 1}}}`,
+		`{"name": true, "inner": {}}`,
+		`{"name": null, "inner": {"count": "a\"b"}}`,
+		`{"inner": {"count": 99999999999999999999}}`,
+	} {
+		var obj1, obj2 Outer
+		err1 := json.Unmarshal([]byte(input), &obj1)
+		err2 := jsoniter.ConfigCompatibleWithStandardLibrary.Unmarshal([]byte(input), &obj2)
+		typeErr1 := err1.(*json.UnmarshalTypeError)
+		typeErr2, ok := err2.(*json.UnmarshalTypeError)
+		should.True(ok, input)
+		should.Equal(typeErr1.Value, typeErr2.Value, input)
+		should.Equal(typeErr1.Type, typeErr2.Type, input)
+		should.Equal(typeErr1.Struct, typeErr2.Struct, input)
+		should.Equal(typeErr1.Field, typeErr2.Field, input)
+		should.Equal(typeErr1.Offset, typeErr2.Offset, input)
+	}
+}
+
+func Test_standard_library_syntax_error_after_unmarshal_type_error(t *testing.T) {
+	should := require.New(t)
+	type Inner struct {
+		Count int `json:"count"`
+	}
+	type Outer struct {
+		Name string  `json:"name"`
+		List []Inner `json:"list"`
+	}
+	for _, input := range []string{
+		`{"name": 1, "x": }`,
+		`{"name": 1} x`,
+		`{"name": 1}}`,
+		`{"name": [1, 2}`,
+		`{"list": [{"count": "a"}, 1 2]}`,
+		`{"list": [{"count": "a"}`,
+	} {
+		var obj1, obj2 Outer
+		err1 := json.Unmarshal([]byte(input), &obj1)
+		err2 := jsoniter.ConfigCompatibleWithStandardLibrary.Unmarshal([]byte(input), &obj2)
+		syntaxErr, ok := err2.(*json.SyntaxError)
+		should.True(ok, input)
+		should.Equal(err1.(*json.SyntaxError).Offset, syntaxErr.Offset, input)
+		should.Equal(err1.Error(), syntaxErr.Error(), input)
+	}
+}
+
+func Test_standard_library_errors_from_decoder(t *testing.T) {
+	should := require.New(t)
+	input := `{"count": 1} {"count": "x"}`
+	var obj struct {
+		Count int `json:"count"`
+	}
+	stdDecoder := json.NewDecoder(bytes.NewBufferString(input))
+	should.Nil(stdDecoder.Decode(&obj))
+	stdErr := stdDecoder.Decode(&obj).(*json.UnmarshalTypeError)
+	decoder := jsoniter.ConfigCompatibleWithStandardLibrary.NewDecoder(bytes.NewBufferString(input))
+	should.Nil(decoder.Decode(&obj))
+	err := decoder.Decode(&obj)
+	typeErr, ok := err.(*json.UnmarshalTypeError)
+	should.True(ok)
+	should.Equal("string", typeErr.Value)
+	should.Equal(stdErr.Offset, typeErr.Offset)
+	should.Equal("count", typeErr.Field)
+
+	decoder = jsoniter.ConfigCompatibleWithStandardLibrary.NewDecoder(bytes.NewBufferString(`[1] [2,}`))
+	var arr []int
+	should.Nil(decoder.Decode(&arr))
+	err = decoder.Decode(&arr)
+	_, ok = err.(*json.SyntaxError)
+	should.True(ok)
+
+	// the value of the wrong type is skipped, the same as json.Decoder
+	decoder = jsoniter.ConfigCompatibleWithStandardLibrary.NewDecoder(bytes.NewBufferString(`{"count": "x"} {"count": 2} [1,`))
+	_, ok = decoder.Decode(&obj).(*json.UnmarshalTypeError)
+	should.True(ok)
+	should.Nil(decoder.Decode(&obj))
+	should.Equal(2, obj.Count)
+	should.Equal(io.ErrUnexpectedEOF, decoder.Decode(&arr))
+	stdDecoder = json.NewDecoder(bytes.NewBufferString(`[1,`))
+	should.Equal(io.ErrUnexpectedEOF, stdDecoder.Decode(&arr))
+}
+
+var errRejected = errors.New("rejected")
+
+type rejectingUnmarshaler struct{}
+
+func (*rejectingUnmarshaler) UnmarshalJSON([]byte) error {
+	return errRejected
+}
+
+type rejectingTextUnmarshaler struct{}
+
+func (*rejectingTextUnmarshaler) UnmarshalText([]byte) error {
+	return errRejected
+}
+
+func Test_standard_library_unmarshaler_error(t *testing.T) {
+	should := require.New(t)
+	var obj struct {
+		A rejectingUnmarshaler
+		B rejectingTextUnmarshaler
+	}
+	for _, input := range []string{`{"A": 1}`, `{"B": "x"}`} {
+		err := jsoniter.ConfigCompatibleWithStandardLibrary.Unmarshal([]byte(input), &obj)
+		should.Equal(json.Unmarshal([]byte(input), &obj), err, input)
+		should.True(errors.Is(err, errRejected), input)
+		// the other configs keep the error as DecodeError.Err
+		should.True(errors.Is(jsoniter.Unmarshal([]byte(input), &obj), errRejected), input)
+	}
+}
+
+func Test_standard_library_invalid_unmarshal_error(t *testing.T) {
+	should := require.New(t)
+	var obj map[string]interface{}
+	err := jsoniter.ConfigCompatibleWithStandardLibrary.Unmarshal([]byte(`{}`), obj)
+	_, ok := err.(*json.InvalidUnmarshalError)
+	should.True(ok)
+	err = jsoniter.ConfigCompatibleWithStandardLibrary.NewDecoder(bytes.NewBufferString(`{}`)).Decode(nil)
+	_, ok = err.(*json.InvalidUnmarshalError)
+	should.True(ok)
+}
+
+type failingMarshaler struct{}
+
+func (failingMarshaler) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("failed")
+}
+
+func Test_standard_library_marshaler_error(t *testing.T) {
+	should := require.New(t)
+	obj := struct {
+		Field []failingMarshaler
+	}{[]failingMarshaler{{}}}
+	_, err := jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(obj)
+	marshalerErr, ok := err.(*json.MarshalerError)
+	should.True(ok)
+	should.Equal("failed", marshalerErr.Err.Error())
+	_, err = jsoniter.ConfigDefault.Marshal(obj)
+	should.NotNil(err)
+	_, ok = err.(*json.MarshalerError)
+	should.False(ok)
+}
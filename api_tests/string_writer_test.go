@@ -0,0 +1,135 @@
+package test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_read_string_to_writer(t *testing.T) {
+	should := require.New(t)
+	for _, input := range []string{
+		`""`, `"abc"`, `"a\"b\\c\/d\b\f\n\r\t"`, `"é😀é😀"`, `"\ud800x"`,
+		`"` + strings.Repeat(`abcé`, 5000) + `"`,
+	} {
+		expected := jsoniter.ParseString(jsoniter.ConfigDefault, input).ReadString()
+		for _, bufSize := range []int{1, 2, 3, 7, 4096} {
+			var output bytes.Buffer
+			iter := jsoniter.Parse(jsoniter.ConfigDefault, iotest.OneByteReader(strings.NewReader(`[`+input+`,1]`)), bufSize)
+			should.True(iter.ReadArray())
+			written := iter.ReadStringTo(&output)
+			should.Nil(iter.Error, input)
+			should.Equal(expected, output.String(), input)
+			should.Equal(int64(len(expected)), written)
+			should.True(iter.ReadArray())
+			should.Equal(1, iter.ReadInt())
+		}
+	}
+	var output bytes.Buffer
+	iter := jsoniter.ParseString(jsoniter.ConfigDefault, `null`)
+	should.Equal(int64(0), iter.ReadStringTo(&output))
+	should.Nil(iter.Error)
+	for _, input := range []string{`"abc`, `"a\`, `"a\x"`, "\"a\nb\"", `123`} {
+		iter := jsoniter.Parse(jsoniter.ConfigDefault, strings.NewReader(input), 2)
+		iter.ReadStringTo(&output)
+		should.NotNil(iter.Error, input)
+	}
+}
+
+type failingWriter struct {
+	err error
+}
+
+func (writer failingWriter) Write(p []byte) (int, error) {
+	return 0, writer.err
+}
+
+func Test_read_string_to_writer_errors(t *testing.T) {
+	should := require.New(t)
+	failure := errors.New("disk full")
+	iter := jsoniter.ParseString(jsoniter.ConfigDefault, `"abc"`)
+	iter.ReadStringTo(failingWriter{failure})
+	should.True(errors.Is(iter.Error, failure))
+
+	iter = jsoniter.ParseString(jsoniter.Config{MaxStringLength: 3}.Froze(), `"abcd"`)
+	var output bytes.Buffer
+	iter.ReadStringTo(&output)
+	should.Equal("MaxStringLength", limitOf(iter.Error))
+}
+
+func Test_read_string_to_writer_utf8_policy(t *testing.T) {
+	should := require.New(t)
+	reject := jsoniter.Config{InvalidUTF8: jsoniter.InvalidUTF8Reject}.Froze()
+	replace := jsoniter.Config{InvalidUTF8: jsoniter.InvalidUTF8Replace}.Froze()
+	for _, bufSize := range []int{1, 2, 3, 4096} {
+		var output bytes.Buffer
+		iter := jsoniter.Parse(reject, strings.NewReader(`"é😀x"`), bufSize)
+		iter.ReadStringTo(&output)
+		should.Nil(iter.Error)
+		should.Equal("é😀x", output.String())
+
+		for _, input := range []string{"\"a\xffb\"", "\"a\xf0\x9f\"", "\"a\xf0\x9f\\n\""} {
+			output.Reset()
+			iter = jsoniter.Parse(reject, strings.NewReader(input), bufSize)
+			iter.ReadStringTo(&output)
+			should.NotNil(iter.Error, input)
+
+			output.Reset()
+			iter = jsoniter.Parse(replace, strings.NewReader(input), bufSize)
+			iter.ReadStringTo(&output)
+			should.Nil(iter.Error)
+			should.Equal(jsoniter.ParseString(replace, input).ReadString(), output.String())
+		}
+	}
+}
+
+func Test_read_base64_to_writer(t *testing.T) {
+	should := require.New(t)
+	data := make([]byte, 10000)
+	rand.New(rand.NewSource(1)).Read(data)
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.RawURLEncoding} {
+		encoded := encoding.EncodeToString(data)
+		// escaped slashes and line breaks are allowed
+		input := `"` + strings.Replace(encoded[:100], "/", `\/`, -1) + `\n` + encoded[100:] + `"`
+		for _, bufSize := range []int{1, 5, 4096} {
+			var output bytes.Buffer
+			iter := jsoniter.Parse(jsoniter.ConfigDefault, strings.NewReader(input), bufSize)
+			written := iter.ReadBase64To(&output, encoding)
+			should.Nil(iter.Error)
+			should.Equal(int64(len(data)), written)
+			should.Equal(data, output.Bytes())
+		}
+		// the decoded bytes are written in chunks of a few KB, not a few bytes at a time
+		writes := &countingWriter{}
+		iter := jsoniter.Parse(jsoniter.ConfigDefault, strings.NewReader(input), 5)
+		iter.ReadBase64To(writes, encoding)
+		should.Nil(iter.Error)
+		should.True(writes.count <= 4, writes.count)
+	}
+	var output bytes.Buffer
+	iter := jsoniter.ParseString(jsoniter.ConfigDefault, `"aGVsbG8="`)
+	iter.ReadBase64To(&output, nil)
+	should.Nil(iter.Error)
+	should.Equal("hello", output.String())
+	for _, input := range []string{`"aGVsbG8"`, `"aGV*bG8="`, `"aGVsbG8=aGVs"`, `"a"`} {
+		iter := jsoniter.ParseString(jsoniter.ConfigDefault, input)
+		iter.ReadBase64To(&output, nil)
+		should.NotNil(iter.Error, input)
+	}
+}
+
+type countingWriter struct {
+	count int
+}
+
+func (writer *countingWriter) Write(p []byte) (int, error) {
+	writer.count++
+	return len(p), nil
+}
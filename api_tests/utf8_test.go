@@ -0,0 +1,58 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_invalid_utf8_reject(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{InvalidUTF8: jsoniter.InvalidUTF8Reject}.Froze()
+	var str string
+	should.Nil(api.UnmarshalFromString(`"ascii, é and \u00e9 \ud83d\ude00"`, &str))
+	should.Equal("ascii, é and é 😀", str)
+	should.NotNil(api.Unmarshal([]byte("\"a\xffb\""), &str))
+	should.NotNil(api.Unmarshal([]byte("\"a\xffb\\n\""), &str))
+	should.NotNil(api.UnmarshalFromString(`"\ud800"`, &str))
+	should.NotNil(api.UnmarshalFromString(`"\udc00x"`, &str))
+	should.True(api.Valid([]byte(`["é"]`)))
+	should.False(api.Valid([]byte("[\"\xff\"]")))
+	_, err := api.MarshalToString("a\xffb")
+	should.NotNil(err)
+	_, err = jsoniter.Config{InvalidUTF8: jsoniter.InvalidUTF8Reject, EscapeHTML: true}.Froze().MarshalToString("a\xffb")
+	should.NotNil(err)
+	output, err := api.MarshalToString("é")
+	should.Nil(err)
+	should.Equal(`"é"`, output)
+}
+
+func Test_invalid_utf8_replace(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{InvalidUTF8: jsoniter.InvalidUTF8Replace}.Froze()
+	var str string
+	should.Nil(api.Unmarshal([]byte("\"a\xff\xfeb\""), &str))
+	should.Equal("a\uFFFD\uFFFDb", str)
+	should.Nil(api.Unmarshal([]byte("\"\\t\xffé\""), &str))
+	should.Equal("\t\uFFFDé", str)
+	should.Nil(api.UnmarshalFromString(`"\ud800x"`, &str))
+	should.Equal("\uFFFDx", str)
+	should.True(api.Valid([]byte("\"\xff\"")))
+	output, err := api.MarshalToString("a\xffé")
+	should.Nil(err)
+	should.Equal(`"a\ufffdé"`, output)
+}
+
+func Test_invalid_utf8_pass_through(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{InvalidUTF8: jsoniter.InvalidUTF8PassThrough, EscapeHTML: true}.Froze()
+	var str string
+	should.Nil(api.Unmarshal([]byte("\"a\xffb\""), &str))
+	should.Equal("a\xffb", str)
+	should.Nil(api.UnmarshalFromString(`"\ud800"`, &str))
+	should.Equal("\xed\xa0\x80", str)
+	output, err := api.MarshalToString("a\xffb")
+	should.Nil(err)
+	should.Equal("\"a\xffb\"", output)
+}
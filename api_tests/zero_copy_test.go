@@ -0,0 +1,42 @@
+package test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_zero_copy_strings_alias_input(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{ZeroCopyStrings: true}.Froze()
+	input := []byte(`{"plain":"abc","escaped":"a\nc"}`)
+	var val struct {
+		Plain   string
+		Escaped string
+	}
+	should.Nil(api.Unmarshal(input, &val))
+	should.Equal("abc", val.Plain)
+	should.Equal("a\nc", val.Escaped)
+	copy(input[bytes.Index(input, []byte("abc")):], "xyz")
+	copy(input[bytes.Index(input, []byte(`a\nc`)):], "xyz")
+	should.Equal("xyz", val.Plain)
+	should.Equal("a\nc", val.Escaped)
+}
+
+func Test_zero_copy_strings_disabled_for_reader(t *testing.T) {
+	should := require.New(t)
+	api := jsoniter.Config{ZeroCopyStrings: true}.Froze()
+	iter := jsoniter.Parse(api, strings.NewReader(`["abc","def"]`), 4)
+	var val []string
+	iter.ReadVal(&val)
+	should.Equal([]string{"abc", "def"}, val)
+	decoder := jsoniter.NewLineDecoder(api, strings.NewReader("\"abc\"\n\"def\"\n"))
+	var first, second string
+	should.Nil(decoder.Decode(&first))
+	should.Nil(decoder.Decode(&second))
+	should.Equal("abc", first)
+	should.Equal("def", second)
+}
@@ -25,6 +25,39 @@ type Config struct {
 	ValidateJsonRawMessage        bool
 	ObjectFieldMustBeSimpleString bool
 	CaseSensitive                 bool
+	StandardLibraryErrors         bool // return *json.SyntaxError, *json.UnmarshalTypeError and friends
+	CollectAllErrors              bool // skip the values can not be decoded, and return DecodeErrors listing all of them
+	// limits for untrusted input, exceeding them is reported as *LimitError, 0 means no limit
+	MaxDepth         int // nesting of arrays and objects
+	MaxStringLength  int // bytes of a string, after unescaping
+	MaxArrayElements int
+	MaxObjectMembers int
+	MaxInputBytes    int // bytes read from the input, the bytes read ahead into the buffer are not counted
+	// accept JSON5 style comments, trailing commas, unquoted object keys and single-quoted strings,
+	// not supported with the jsoniter_sloppy build tag
+	Relaxed bool
+	// decode the slices in []byte input with this many goroutines, 0 or 1 means sequentially.
+	// Only the slices of at least ParallelDecodeMinBytes (1MB if 0) are decoded in parallel.
+	ParallelDecodeWorkers  int
+	ParallelDecodeMinBytes int
+	// decode the strings without escapes as aliases of the []byte input instead of copying them.
+	// The decoded strings are only valid as long as the input is not modified,
+	// so the input must not be reused or changed after Unmarshal.
+	// The strings read from io.Reader are always copied, as the buffer is reused.
+	ZeroCopyStrings bool
+	// reuse the strings decoded before by ReadString, for the object keys and values repeated in the input.
+	// It is the max number of strings kept by the API, 0 disables interning.
+	// Only the strings up to 64 bytes are interned, interning takes precedence over ZeroCopyStrings.
+	InternStrings int
+	// how the keys repeated in one object are handled by decoding, Valid and Get,
+	// Valid only rejects them by DuplicateKeysError
+	DuplicateKeys DuplicateKeyPolicy
+	// how the invalid UTF-8 bytes and unpaired surrogate escapes in strings are handled by decoding, encoding and Valid,
+	// Valid only rejects them by InvalidUTF8Reject, and not with the jsoniter_sloppy build tag
+	InvalidUTF8 InvalidUTF8Policy
+	// encode and decode big.Int, big.Float and big.Rat by their MarshalJSON and MarshalText methods
+	// as encoding/json, instead of as JSON numbers. big.Float and big.Rat are JSON strings then.
+	BigNumbersAsStrings bool
 }
 
 // API the public interface of this package.
@@ -38,6 +71,11 @@ type API interface {
 	UnmarshalFromString(str string, v interface{}) error
 	Unmarshal(data []byte, v interface{}) error
 	Get(data []byte, path ...interface{}) Any
+	Query(data []byte, path string) Any
+	GetPointer(data []byte, pointer string) Any
+	Set(data []byte, value interface{}, path ...interface{}) ([]byte, error)
+	SetCreatingParents(data []byte, value interface{}, path ...interface{}) ([]byte, error)
+	Delete(data []byte, path ...interface{}) ([]byte, error)
 	NewEncoder(writer io.Writer) *Encoder
 	NewDecoder(reader io.Reader) *Decoder
 	Valid(data []byte) bool
@@ -56,6 +94,8 @@ var ConfigCompatibleWithStandardLibrary = Config{
 	EscapeHTML:             true,
 	SortMapKeys:            true,
 	ValidateJsonRawMessage: true,
+	StandardLibraryErrors:  true,
+	BigNumbersAsStrings:    true,
 }.Froze()
 
 // ConfigFastest marshals float with only 6 digits precision
@@ -80,6 +120,21 @@ type frozenConfig struct {
 	streamPool                    *sync.Pool
 	iteratorPool                  *sync.Pool
 	caseSensitive                 bool
+	standardLibraryErrors         bool
+	collectAllErrors              bool
+	maxDepth                      int
+	maxStringLength               int
+	maxArrayElements              int
+	maxObjectMembers              int
+	maxInputBytes                 int
+	relaxed                       bool
+	parallelDecodeWorkers         int
+	parallelDecodeMinBytes        int
+	zeroCopyStrings               bool
+	stringInterner                *stringInterner
+	duplicateKeys                 DuplicateKeyPolicy
+	invalidUTF8                   InvalidUTF8Policy
+	bigNumbersAsStrings           bool
 }
 
 func (cfg *frozenConfig) initCache() {
@@ -138,6 +193,24 @@ func (cfg Config) Froze() API {
 		onlyTaggedField:               cfg.OnlyTaggedField,
 		disallowUnknownFields:         cfg.DisallowUnknownFields,
 		caseSensitive:                 cfg.CaseSensitive,
+		standardLibraryErrors:         cfg.StandardLibraryErrors,
+		collectAllErrors:              cfg.CollectAllErrors,
+		maxDepth:                      limitOrMax(cfg.MaxDepth),
+		maxStringLength:               limitOrMax(cfg.MaxStringLength),
+		maxArrayElements:              limitOrMax(cfg.MaxArrayElements),
+		maxObjectMembers:              limitOrMax(cfg.MaxObjectMembers),
+		maxInputBytes:                 limitOrMax(cfg.MaxInputBytes),
+		relaxed:                       cfg.Relaxed,
+		parallelDecodeWorkers:         cfg.ParallelDecodeWorkers,
+		parallelDecodeMinBytes:        cfg.ParallelDecodeMinBytes,
+		zeroCopyStrings:               cfg.ZeroCopyStrings,
+		stringInterner:                newStringInterner(cfg.InternStrings),
+		duplicateKeys:                 cfg.DuplicateKeys,
+		invalidUTF8:                   cfg.InvalidUTF8,
+		bigNumbersAsStrings:           cfg.BigNumbersAsStrings,
+	}
+	if api.parallelDecodeMinBytes <= 0 {
+		api.parallelDecodeMinBytes = defaultParallelMinBytes
 	}
 	api.streamPool = &sync.Pool{                    // 缓存stream  便于重复利用 减少GC压力
 		New: func() interface{} {
@@ -328,18 +401,7 @@ func (cfg *frozenConfig) MarshalIndent(v interface{}, prefix, indent string) ([]
 
 func (cfg *frozenConfig) UnmarshalFromString(str string, v interface{}) error {
 	data := []byte(str)
-	iter := cfg.BorrowIterator(data)
-	defer cfg.ReturnIterator(iter)
-	iter.ReadVal(v)
-	c := iter.nextToken()
-	if c == 0 {
-		if iter.Error == io.EOF {
-			return nil
-		}
-		return iter.Error
-	}
-	iter.ReportError("Unmarshal", "there are bytes left after unmarshal")
-	return iter.Error
+	return cfg.Unmarshal(data, v)
 }
 
 func (cfg *frozenConfig) Get(data []byte, path ...interface{}) Any {
@@ -348,19 +410,41 @@ func (cfg *frozenConfig) Get(data []byte, path ...interface{}) Any {
 	return locatePath(iter, path)
 }
 
+// GetPointer gets the value of data by the RFC 6901 JSON Pointer, such as /items/0/name
+func (cfg *frozenConfig) GetPointer(data []byte, pointer string) Any {
+	return getPointer(cfg.Get(data), pointer)
+}
+
+// Query selects the nodes of data by the JSONPath, the values not visited are not parsed
+func (cfg *frozenConfig) Query(data []byte, path string) Any {
+	return queryAny(cfg.Get(data), path)
+}
+
 func (cfg *frozenConfig) Unmarshal(data []byte, v interface{}) error {  // 反序列化
+	if cfg.standardLibraryErrors {
+		if err := checkUnmarshalTarget(v); err != nil {
+			return err
+		}
+	}
 	iter := cfg.BorrowIterator(data)
 	defer cfg.ReturnIterator(iter)
 	iter.ReadVal(v)
 	c := iter.nextToken()
-	if c == 0 {
-		if iter.Error == io.EOF {
-			return nil
-		}
-		return iter.Error
+	if c != 0 {
+		iter.ReportError("Unmarshal", "there are bytes left after unmarshal")
 	}
-	iter.ReportError("Unmarshal", "there are bytes left after unmarshal")
-	return iter.Error
+	if len(iter.collectedErrors) > 0 {
+		return iter.collectedError()
+	}
+	if iter.Error == nil || iter.Error == io.EOF {
+		return nil
+	}
+	err := iter.standardLibraryError(0)
+	if _, isTypeErr := err.(*json.UnmarshalTypeError); isTypeErr && iter.nextToken() != 0 {
+		iter.ReportError("Unmarshal", "there are bytes left after unmarshal")
+		return iter.standardLibraryError(0)
+	}
+	return err
 }
 
 func (cfg *frozenConfig) NewEncoder(writer io.Writer) *Encoder {   //新建编码器
@@ -370,13 +454,17 @@ func (cfg *frozenConfig) NewEncoder(writer io.Writer) *Encoder {   //新建编
 
 func (cfg *frozenConfig) NewDecoder(reader io.Reader) *Decoder {  // 新建解码器
 	iter := Parse(cfg, reader, 512)
-	return &Decoder{iter}
+	return &Decoder{iter: iter}
 }
 
 // 验证数据
 func (cfg *frozenConfig) Valid(data []byte) bool {
 	iter := cfg.BorrowIterator(data)
 	defer cfg.ReturnIterator(iter)
-	iter.Skip()
+	if cfg.duplicateKeys == DuplicateKeysError {
+		iter.skipCheckingKeys()
+	} else {
+		iter.Skip()
+	}
 	return iter.Error == nil
 }
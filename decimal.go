@@ -0,0 +1,431 @@
+package jsoniter
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"unsafe"
+
+	"github.com/modern-go/reflect2"
+)
+
+// Decimal is an arbitrary-precision decimal number, the unscaled value multiplied by 10^-scale.
+// It is decoded from JSON numbers or strings without going through float64,
+// and encoded as JSON number without exponent.
+// The zero value is 0. Decimal is immutable and comparable,
+// the values of different scales are different keys of Go map, such as 1.5 and 1.50.
+type Decimal struct {
+	unscaled string // canonical decimal digits with optional '-', empty for zero
+	scale    int32
+}
+
+// RoundingMode is how the digits dropped by Rescale and Quo are rounded
+type RoundingMode int
+
+const (
+	// RoundHalfEven rounds to the nearest, the ties to the even digit
+	RoundHalfEven RoundingMode = iota
+	// RoundHalfUp rounds to the nearest, the ties away from zero
+	RoundHalfUp
+	// RoundDown rounds toward zero
+	RoundDown
+	// RoundUp rounds away from zero
+	RoundUp
+	// RoundFloor rounds toward negative infinity
+	RoundFloor
+	// RoundCeiling rounds toward positive infinity
+	RoundCeiling
+)
+
+// maxDecimalScale bounds the scale of parsed decimals, to bound the length of encoding without exponent
+const maxDecimalScale = 1 << 16
+
+var decimalType = reflect2.TypeOfPtr((*Decimal)(nil)).Elem()
+
+// NewDecimal returns unscaled * 10^-scale
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	if unscaled == 0 {
+		return Decimal{"", scale}
+	}
+	return Decimal{strconv.FormatInt(unscaled, 10), scale}
+}
+
+// NewDecimalFromBigInt returns unscaled * 10^-scale
+func NewDecimalFromBigInt(unscaled *big.Int, scale int32) Decimal {
+	return decimalOfBig(unscaled, int64(scale))
+}
+
+// ParseDecimal parses the decimal in the syntax of JSON number, such as -12.30 or 1e-3
+func ParseDecimal(str string) (Decimal, error) {
+	val, ok := parseDecimal(str)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal: %q", str)
+	}
+	return val, nil
+}
+
+// MustParseDecimal is ParseDecimal panicking on error
+func MustParseDecimal(str string) Decimal {
+	val, err := ParseDecimal(str)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+func parseDecimal(str string) (Decimal, bool) {
+	i := 0
+	neg := false
+	if i < len(str) && str[i] == '-' {
+		neg = true
+		i++
+	}
+	intStart := i
+	for i < len(str) && str[i] >= '0' && str[i] <= '9' {
+		i++
+	}
+	intDigits := str[intStart:i]
+	if len(intDigits) == 0 || len(intDigits) > 1 && intDigits[0] == '0' {
+		return Decimal{}, false
+	}
+	fracDigits := ""
+	if i < len(str) && str[i] == '.' {
+		i++
+		fracStart := i
+		for i < len(str) && str[i] >= '0' && str[i] <= '9' {
+			i++
+		}
+		fracDigits = str[fracStart:i]
+		if len(fracDigits) == 0 {
+			return Decimal{}, false
+		}
+	}
+	exponent := int64(0)
+	if i < len(str) && (str[i] == 'e' || str[i] == 'E') {
+		i++
+		expStart := i
+		if i < len(str) && (str[i] == '+' || str[i] == '-') {
+			i++
+		}
+		if i == len(str) {
+			return Decimal{}, false
+		}
+		var err error
+		exponent, err = strconv.ParseInt(str[expStart:], 10, 32)
+		if err != nil {
+			return Decimal{}, false
+		}
+		i = len(str)
+	}
+	if i != len(str) {
+		return Decimal{}, false
+	}
+	scale := int64(len(fracDigits)) - exponent
+	if scale > maxDecimalScale || scale < -maxDecimalScale {
+		return Decimal{}, false
+	}
+	// copied, since the string may be a part of the input
+	digits := string(append([]byte(intDigits), fracDigits...))
+	for len(digits) > 0 && digits[0] == '0' {
+		digits = digits[1:]
+	}
+	if digits == "" {
+		return Decimal{"", int32(scale)}, true
+	}
+	if neg {
+		digits = "-" + digits
+	}
+	return Decimal{digits, int32(scale)}, true
+}
+
+func decimalOfBig(unscaled *big.Int, scale int64) Decimal {
+	if scale > math.MaxInt32 || scale < math.MinInt32 {
+		panic("decimal scale overflow")
+	}
+	if unscaled.Sign() == 0 {
+		return Decimal{"", int32(scale)}
+	}
+	return Decimal{unscaled.String(), int32(scale)}
+}
+
+// Unscaled returns the unscaled value
+func (d Decimal) Unscaled() *big.Int {
+	unscaled := new(big.Int)
+	if d.unscaled != "" {
+		unscaled.SetString(d.unscaled, 10)
+	}
+	return unscaled
+}
+
+// Scale returns the number of digits after the decimal point, negative for the trailing zeros of integer
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Sign returns -1, 0 or 1
+func (d Decimal) Sign() int {
+	if d.unscaled == "" {
+		return 0
+	}
+	if d.unscaled[0] == '-' {
+		return -1
+	}
+	return 1
+}
+
+// IsZero returns true if the value is 0 of any scale
+func (d Decimal) IsZero() bool {
+	return d.unscaled == ""
+}
+
+// String returns the decimal without exponent, with exactly Scale digits after the decimal point
+func (d Decimal) String() string {
+	return string(d.appendTo(nil))
+}
+
+func (d Decimal) appendTo(buf []byte) []byte {
+	digits := d.unscaled
+	if digits == "" {
+		digits = "0"
+	} else if digits[0] == '-' {
+		buf = append(buf, '-')
+		digits = digits[1:]
+	}
+	if d.scale <= 0 {
+		buf = append(buf, digits...)
+		if d.unscaled != "" {
+			for i := int32(0); i < -d.scale; i++ {
+				buf = append(buf, '0')
+			}
+		}
+		return buf
+	}
+	scale := int(d.scale)
+	if len(digits) <= scale {
+		buf = append(buf, '0', '.')
+		for i := len(digits); i < scale; i++ {
+			buf = append(buf, '0')
+		}
+		return append(buf, digits...)
+	}
+	buf = append(buf, digits[:len(digits)-scale]...)
+	buf = append(buf, '.')
+	return append(buf, digits[len(digits)-scale:]...)
+}
+
+// Float64 returns the nearest float64
+func (d Decimal) Float64() float64 {
+	val, _ := strconv.ParseFloat(d.String(), 64)
+	return val
+}
+
+// Rescale returns the decimal of the scale, the dropped digits are rounded by mode
+func (d Decimal) Rescale(scale int32, mode RoundingMode) Decimal {
+	if scale >= d.scale {
+		unscaled := d.Unscaled()
+		return decimalOfBig(unscaled.Mul(unscaled, pow10Big(int64(scale)-int64(d.scale))), int64(scale))
+	}
+	return decimalOfBig(quoRound(d.Unscaled(), pow10Big(int64(d.scale)-int64(scale)), mode), int64(scale))
+}
+
+// Round returns the decimal of the scale, rounded half to even
+func (d Decimal) Round(scale int32) Decimal {
+	return d.Rescale(scale, RoundHalfEven)
+}
+
+// Neg returns -d
+func (d Decimal) Neg() Decimal {
+	switch d.Sign() {
+	case 1:
+		return Decimal{"-" + d.unscaled, d.scale}
+	case -1:
+		return Decimal{d.unscaled[1:], d.scale}
+	}
+	return d
+}
+
+// Abs returns |d|
+func (d Decimal) Abs() Decimal {
+	if d.Sign() < 0 {
+		return d.Neg()
+	}
+	return d
+}
+
+// Add returns d + other exactly, of the larger scale
+func (d Decimal) Add(other Decimal) Decimal {
+	x, y, scale := alignDecimals(d, other)
+	return decimalOfBig(x.Add(x, y), scale)
+}
+
+// Sub returns d - other exactly, of the larger scale
+func (d Decimal) Sub(other Decimal) Decimal {
+	x, y, scale := alignDecimals(d, other)
+	return decimalOfBig(x.Sub(x, y), scale)
+}
+
+// Mul returns d * other exactly, the scale is the sum of the scales
+func (d Decimal) Mul(other Decimal) Decimal {
+	x := d.Unscaled()
+	return decimalOfBig(x.Mul(x, other.Unscaled()), int64(d.scale)+int64(other.scale))
+}
+
+// Quo returns d / other of the scale, rounded by mode. It panics if other is zero.
+func (d Decimal) Quo(other Decimal, scale int32, mode RoundingMode) Decimal {
+	if other.IsZero() {
+		panic("division by zero")
+	}
+	num := d.Unscaled()
+	den := other.Unscaled()
+	// d / other = num / den * 10^(other.scale - d.scale), to be multiplied by 10^scale
+	shift := int64(scale) - int64(d.scale) + int64(other.scale)
+	if shift >= 0 {
+		num.Mul(num, pow10Big(shift))
+	} else {
+		den.Mul(den, pow10Big(-shift))
+	}
+	return decimalOfBig(quoRound(num, den, mode), int64(scale))
+}
+
+// Cmp returns -1, 0 or 1 if d is less than, equal to, or greater than other, regardless of the scales
+func (d Decimal) Cmp(other Decimal) int {
+	if d.unscaled == other.unscaled && d.scale == other.scale {
+		return 0
+	}
+	if d.Sign() != other.Sign() {
+		if d.Sign() < other.Sign() {
+			return -1
+		}
+		return 1
+	}
+	x, y, _ := alignDecimals(d, other)
+	return x.Cmp(y)
+}
+
+// Equal returns true if d and other are the same number, regardless of the scales
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+// alignDecimals returns the unscaled values of the larger scale
+func alignDecimals(x Decimal, y Decimal) (*big.Int, *big.Int, int64) {
+	xUnscaled, yUnscaled := x.Unscaled(), y.Unscaled()
+	if x.scale > y.scale {
+		yUnscaled.Mul(yUnscaled, pow10Big(int64(x.scale)-int64(y.scale)))
+		return xUnscaled, yUnscaled, int64(x.scale)
+	}
+	xUnscaled.Mul(xUnscaled, pow10Big(int64(y.scale)-int64(x.scale)))
+	return xUnscaled, yUnscaled, int64(y.scale)
+}
+
+func pow10Big(n int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+}
+
+// quoRound returns num / den rounded by mode
+func quoRound(num *big.Int, den *big.Int, mode RoundingMode) *big.Int {
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() == 0 {
+		return quo
+	}
+	sign := num.Sign() * den.Sign()
+	var away bool
+	switch mode {
+	case RoundUp:
+		away = true
+	case RoundFloor:
+		away = sign < 0
+	case RoundCeiling:
+		away = sign > 0
+	case RoundHalfUp, RoundHalfEven:
+		half := rem.Abs(rem).Lsh(rem, 1).Cmp(new(big.Int).Abs(den))
+		away = half > 0 || half == 0 && (mode == RoundHalfUp || quo.Bit(0) == 1)
+	}
+	if away {
+		quo.Add(quo, big.NewInt(int64(sign)))
+	}
+	return quo
+}
+
+// MarshalText implements encoding.TextMarshaler, used for the keys of map
+func (d Decimal) MarshalText() ([]byte, error) {
+	return d.appendTo(nil), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, used for the keys of map
+func (d *Decimal) UnmarshalText(text []byte) error {
+	val, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = val
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for encoding/json
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return d.appendTo(nil), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler for encoding/json, both number and string are accepted
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	iter := ConfigDefault.BorrowIterator(data)
+	defer ConfigDefault.ReturnIterator(iter)
+	(&decimalCodec{}).Decode(unsafe.Pointer(d), iter)
+	if iter.Error != nil && iter.Error != io.EOF {
+		return iter.Error
+	}
+	return nil
+}
+
+func createDecoderOfDecimal(ctx *ctx, typ reflect2.Type) ValDecoder {
+	if typ == decimalType {
+		return &decimalCodec{}
+	}
+	return nil
+}
+
+func createEncoderOfDecimal(ctx *ctx, typ reflect2.Type) ValEncoder {
+	if typ == decimalType {
+		return &decimalCodec{}
+	}
+	return nil
+}
+
+type decimalCodec struct {
+}
+
+func (codec *decimalCodec) Decode(ptr unsafe.Pointer, iter *Iterator) {
+	var str string
+	switch iter.WhatIsNext() {
+	case NilValue:
+		iter.skipFourBytes('n', 'u', 'l', 'l')
+		return
+	case StringValue:
+		str = iter.ReadString()
+	case NumberValue:
+		str = iter.readNumberAsString()
+	default:
+		iter.ReportError("decimalCodec", "expect number or string for decimal")
+		return
+	}
+	if iter.Error != nil && iter.Error != io.EOF {
+		return
+	}
+	val, ok := parseDecimal(str)
+	if !ok {
+		iter.ReportError("decimalCodec", "invalid decimal: "+strconv.Quote(str))
+		return
+	}
+	*(*Decimal)(ptr) = val
+}
+
+func (codec *decimalCodec) Encode(ptr unsafe.Pointer, stream *Stream) {
+	stream.buf = (*Decimal)(ptr).appendTo(stream.buf)
+}
+
+func (codec *decimalCodec) IsEmpty(ptr unsafe.Pointer) bool {
+	return *(*Decimal)(ptr) == Decimal{}
+}
@@ -1,9 +1,11 @@
 package jsoniter
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
 )
 
 // ValueType the type for JSON element
@@ -76,6 +78,23 @@ type Iterator struct {
 	tail             int
 	captureStartedAt int
 	captured         []byte
+	copyStartedAt    int
+	copyingTo        *valueWriter // the value being copied by CopyValueTo
+	consumed         int64 // bytes discarded by loadMore, to report absolute positions
+	consumedLines    int
+	consumedColumn   int
+	collectedErrors  []*DecodeError // the values skipped in CollectAllErrors mode
+	depth            int
+	afterSeparator   bool // the last token is [, { or , in relaxed mode, the comma after it is not dropped
+	inputCut         bool // the tail is cut at MaxInputBytes
+	recording        valueRecording
+	inParallelWorker bool // decoding an element of the slice decoded in parallel
+	smallArrayEnd    int  // the arrays starting before it are too small to be decoded in parallel
+	ctx              context.Context
+	ctxDone          <-chan struct{}
+	ctxCountdown     int
+	spareBuf         []byte // read by another goroutine if ctx is bound
+	ctxReader        *contextReader
 	Error            error
 	Attachment       interface{} // open for customized decoder
 }
@@ -104,13 +123,15 @@ func Parse(cfg API, reader io.Reader, bufSize int) *Iterator {
 
 // ParseBytes creates an Iterator instance from byte array
 func ParseBytes(cfg API, input []byte) *Iterator {
-	return &Iterator{
+	iter := &Iterator{
 		cfg:    cfg.(*frozenConfig),
 		reader: nil,
 		buf:    input,
 		head:   0,
 		tail:   len(input),
 	}
+	iter.limitInputBytes()
+	return iter
 }
 
 // ParseString creates an Iterator instance from string
@@ -125,24 +146,37 @@ func (iter *Iterator) Pool() IteratorPool {
 
 // Reset reuse iterator instance by specifying another reader
 func (iter *Iterator) Reset(reader io.Reader) *Iterator { // 复用迭代器 提供新的reader
+	iter.stopContextReader()
 	iter.reader = reader
 	iter.head = 0
 	iter.tail = 0
+	iter.depth = 0
+	iter.resetPosition()
+	iter.collectedErrors = nil
 	return iter
 }
 
 // ResetBytes reuse iterator instance by specifying another byte array as input
 func (iter *Iterator) ResetBytes(input []byte) *Iterator { // 复用迭代器 提供[]byte作为输入
+	iter.stopContextReader()
 	iter.reader = nil
 	iter.buf = input
 	iter.head = 0
 	iter.tail = len(input)
+	iter.depth = 0
+	iter.resetPosition()
+	iter.collectedErrors = nil
+	iter.limitInputBytes()
 	return iter
 }
 
 // WhatIsNext gets ValueType of relatively next json element
 func (iter *Iterator) WhatIsNext() ValueType {
-	valueType := valueTypes[iter.nextToken()]
+	c := iter.nextToken()
+	valueType := valueTypes[c]
+	if c == '\'' && iter.cfg.relaxed {
+		valueType = StringValue
+	}
 	iter.unreadByte()
 	return valueType
 }
@@ -180,9 +214,17 @@ func (iter *Iterator) nextToken() byte {
 			switch c {
 			case ' ', '\n', '\t', '\r':
 				continue
+			case ',', '/':
+				if iter.cfg.relaxed {
+					iter.head = i + 1
+					return iter.nextRelaxedToken(c)
+				}
 			}
 			iter.head = i + 1 // 剔除空白元素后 将当前迭代器的head指向下一个非空白的元素  便于接下来的元素迭代
-			return c          // 返回当前位置的非空白内容
+			if iter.cfg.relaxed {
+				iter.afterSeparator = c == '[' || c == '{'
+			}
+			return c // 返回当前位置的非空白内容
 		}
 		if !iter.loadMore() {
 			return 0
@@ -215,8 +257,16 @@ func (iter *Iterator) ReportError(operation string, msg string) {
 		contextEnd = iter.tail
 	}
 	context := string(iter.buf[contextStart:contextEnd])
-	iter.Error = fmt.Errorf("%s: %s, error found in #%v byte of ...|%s|..., bigger context ...|%s|...",
-		operation, msg, iter.head-peekStart, parsing, context)
+	decodeErr := &DecodeError{
+		Operation: operation,
+		Message:   msg,
+		snippet: fmt.Sprintf("error found in #%v byte of ...|%s|..., bigger context ...|%s|...",
+			iter.head-peekStart, parsing, context),
+		endOfInput: iter.Error == io.EOF,
+		depth:      iter.depth,
+	}
+	decodeErr.Offset, decodeErr.Line, decodeErr.Column = iter.position()
+	iter.Error = decodeErr
 }
 
 // CurrentBuffer gets current buffer as string for debugging purpose
@@ -244,6 +294,9 @@ func (iter *Iterator) readByte() (ret byte) {
 }
 
 func (iter *Iterator) loadMore() bool {
+	if !iter.checkInputBytes() {
+		return false
+	}
 	if iter.reader == nil {
 		if iter.Error == nil {
 			iter.head = iter.tail
@@ -256,8 +309,20 @@ func (iter *Iterator) loadMore() bool {
 			iter.buf[iter.captureStartedAt:iter.tail]...)
 		iter.captureStartedAt = 0
 	}
+	if iter.recording.marks != 0 {
+		iter.recording.recorded = append(iter.recording.recorded, iter.buf[:iter.tail]...)
+	}
+	if iter.copyingTo != nil {
+		iter.copyingTo.write(iter.buf[iter.copyStartedAt:iter.tail])
+		// nothing more to copy from the buffer, unless it is refilled
+		iter.copyStartedAt = iter.tail
+	}
+	if iter.ctxDone != nil && !iter.checkContextNow("loadMore") {
+		return false
+	}
+	consumed, consumedLines, consumedColumn := iter.positionAfterDiscard()
 	for {
-		n, err := iter.reader.Read(iter.buf)
+		n, err := iter.read()
 		if n == 0 {
 			if err != nil {
 				if iter.Error == nil {
@@ -266,9 +331,12 @@ func (iter *Iterator) loadMore() bool {
 				return false
 			}
 		} else {
+			iter.consumed, iter.consumedLines, iter.consumedColumn = consumed, consumedLines, consumedColumn
 			iter.head = 0
 			iter.tail = n
-			return true
+			iter.copyStartedAt = 0
+			iter.limitInputBytes()
+			return iter.tail > 0 || iter.checkInputBytes()
 		}
 	}
 }
@@ -302,20 +370,34 @@ func (iter *Iterator) Read() interface{} {
 		iter.ReadArrayCB(func(iter *Iterator) bool {
 			var elem interface{}
 			iter.ReadVal(&elem)
+			if iter.Error != nil && iter.Error != io.EOF {
+				iter.addErrorContext("", strconv.Itoa(len(arr)))
+				return false
+			}
 			arr = append(arr, elem)
 			return true
 		})
 		return arr
 	case ObjectValue:
 		obj := map[string]interface{}{}
+		keys := iter.newObjectKeys()
 		iter.ReadMapCB(func(Iter *Iterator, field string) bool {
+			if keys.policy != DuplicateKeysDefault && keys.skip(iter, field) {
+				iter.Skip()
+				return iter.Error == nil || iter.Error == io.EOF
+			}
 			var elem interface{}
 			iter.ReadVal(&elem)
+			if iter.Error != nil && iter.Error != io.EOF {
+				iter.addErrorContext("", field)
+				return false
+			}
 			obj[field] = elem
 			return true
 		})
 		return obj
 	default:
+		iter.nextToken() // read the offending byte, so that the error is found at it
 		iter.ReportError("Read", fmt.Sprintf("unexpected value type: %v", valueType))
 		return nil
 	}
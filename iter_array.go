@@ -28,6 +28,10 @@ func (iter *Iterator) ReadArray() (ret bool) {
 func (iter *Iterator) ReadArrayCB(callback func(*Iterator) bool) (ret bool) {
 	c := iter.nextToken()
 	if c == '[' {
+		if !iter.incrementDepth() {
+			return false
+		}
+		defer iter.decrementDepth()
 		c = iter.nextToken()
 		if c != ']' {
 			iter.unreadByte()
@@ -35,8 +39,8 @@ func (iter *Iterator) ReadArrayCB(callback func(*Iterator) bool) (ret bool) {
 				return false
 			}
 			c = iter.nextToken()
-			for c == ',' {
-				if !callback(iter) {
+			for length := 2; c == ','; length++ {
+				if !iter.checkArrayElements(length) || !callback(iter) {
 					return false
 				}
 				c = iter.nextToken()
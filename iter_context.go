@@ -0,0 +1,145 @@
+package jsoniter
+
+import (
+	"context"
+	"io"
+)
+
+// contextCheckInterval is how many array elements, object members and nested values
+// are read between the checks of the context bound to the iterator
+const contextCheckInterval = 256
+
+// SetContext binds ctx to the iterator, nil unbinds it.
+// Once ctx is done, decoding stops with *DecodeError wrapping ctx.Err(),
+// the position of the error tells how far decoding got.
+// ctx is checked before every refill of the buffer, and periodically while reading arrays and objects.
+// While ctx is bound, io.Reader is read by a goroutine, which ends once ctx is done or unbound.
+// The blocking read of io.Reader is abandoned when ctx is done, leaving the goroutine blocked until Read returns,
+// the reader should not be used after that.
+func (iter *Iterator) SetContext(ctx context.Context) {
+	iter.stopContextReader()
+	iter.ctx = ctx
+	iter.ctxDone = nil
+	iter.ctxCountdown = contextCheckInterval
+	if ctx != nil {
+		iter.ctxDone = ctx.Done()
+	}
+}
+
+// Context returns the context bound by SetContext, or context.Background() if none
+func (iter *Iterator) Context() context.Context {
+	if iter.ctx == nil {
+		return context.Background()
+	}
+	return iter.ctx
+}
+
+// checkContext checks the bound context every contextCheckInterval calls
+func (iter *Iterator) checkContext(operation string) bool {
+	if iter.ctxDone == nil {
+		return true
+	}
+	iter.ctxCountdown--
+	if iter.ctxCountdown > 0 {
+		return true
+	}
+	iter.ctxCountdown = contextCheckInterval
+	return iter.checkContextNow(operation)
+}
+
+// checkContextNow reports the error of the bound context if it is done
+func (iter *Iterator) checkContextNow(operation string) bool {
+	select {
+	case <-iter.ctxDone:
+		iter.reportContextError(operation)
+		return false
+	default:
+		return true
+	}
+}
+
+func (iter *Iterator) reportContextError(operation string) {
+	if iter.Error != nil && iter.Error != io.EOF {
+		return
+	}
+	err := iter.ctx.Err()
+	iter.Error = nil
+	iter.ReportError(operation, err.Error())
+	iter.asDecodeError().Err = err
+}
+
+func isContextError(err error) bool {
+	decodeErr, isDecodeErr := err.(*DecodeError)
+	if !isDecodeErr {
+		return false
+	}
+	return decodeErr.Err == context.Canceled || decodeErr.Err == context.DeadlineExceeded
+}
+
+type readResult struct {
+	n   int
+	err error
+}
+
+// contextReader reads from the reader in a goroutine as long as the context is bound,
+// so that the blocking read can be abandoned once the context is done
+type contextReader struct {
+	requests chan []byte // the buffer to read into
+	results  chan readResult
+}
+
+func startContextReader(reader io.Reader, done <-chan struct{}) *contextReader {
+	ctxReader := &contextReader{
+		requests: make(chan []byte, 1),
+		results:  make(chan readResult, 1),
+	}
+	go func() {
+		for {
+			select {
+			case buf, ok := <-ctxReader.requests:
+				if !ok {
+					return
+				}
+				n, err := reader.Read(buf)
+				ctxReader.results <- readResult{n, err}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return ctxReader
+}
+
+// stopContextReader ends the goroutine reading for the bound context, once its current read returns
+func (iter *Iterator) stopContextReader() {
+	if iter.ctxReader != nil {
+		close(iter.ctxReader.requests)
+		iter.ctxReader = nil
+	}
+}
+
+// read fills the buffer from reader. If a context is bound, the read is done by the goroutine of contextReader
+// into the spare buffer, so that it can be abandoned once the context is done.
+func (iter *Iterator) read() (int, error) {
+	if iter.ctxDone == nil {
+		return iter.reader.Read(iter.buf)
+	}
+	if iter.ctxReader == nil {
+		iter.ctxReader = startContextReader(iter.reader, iter.ctxDone)
+	}
+	if len(iter.spareBuf) != len(iter.buf) {
+		iter.spareBuf = make([]byte, len(iter.buf))
+	}
+	iter.ctxReader.requests <- iter.spareBuf
+	select {
+	case result := <-iter.ctxReader.results:
+		iter.buf, iter.spareBuf = iter.spareBuf, iter.buf
+		return result.n, result.err
+	case <-iter.ctxDone:
+		// still written by the abandoned read
+		iter.spareBuf = nil
+		iter.stopContextReader()
+		iter.reportContextError("loadMore")
+		return 0, iter.Error
+	}
+}
@@ -0,0 +1,133 @@
+package jsoniter
+
+import (
+	"io"
+	"strings"
+)
+
+// CopyValueTo validates the next value and copies it as is to writer,
+// the value is written as the buffer is refilled, instead of being held in memory.
+// The value may be partially written if it is found invalid. It returns the bytes written.
+func (iter *Iterator) CopyValueTo(writer io.Writer) int64 {
+	return iter.copyValue(&valueWriter{writer: writer})
+}
+
+// CopyCompactValueTo is CopyValueTo without the insignificant whitespaces, as json.Compact
+func (iter *Iterator) CopyCompactValueTo(writer io.Writer) int64 {
+	return iter.copyValue(&valueWriter{writer: writer, compact: true})
+}
+
+// CopyIndentedValueTo is CopyValueTo re-indented with prefix and indent, as json.Indent
+func (iter *Iterator) CopyIndentedValueTo(writer io.Writer, prefix, indent string) int64 {
+	return iter.copyValue(&valueWriter{writer: writer, compact: true, indenting: true, prefix: prefix, indent: indent})
+}
+
+func (iter *Iterator) copyValue(out *valueWriter) int64 {
+	if iter.copyingTo != nil {
+		panic("already copying value")
+	}
+	iter.WhatIsNext()
+	iter.copyingTo, iter.copyStartedAt = out, iter.head
+	iter.Skip()
+	if iter.head > iter.copyStartedAt {
+		out.write(iter.buf[iter.copyStartedAt:iter.head])
+	}
+	iter.copyingTo = nil
+	if out.err != nil {
+		iter.reportUnderlyingError("CopyValueTo", out.err)
+	}
+	return out.written
+}
+
+// valueWriter writes the value copied, compacted or re-indented.
+// It keeps the state between the writes, since the value is written by pieces.
+type valueWriter struct {
+	writer     io.Writer
+	compact    bool
+	indenting  bool
+	prefix     string
+	indent     string
+	inString   bool
+	escaped    bool
+	depth      int
+	needIndent bool // after { or [, the line break is written unless the container is empty
+	scratch    []byte
+	written    int64
+	err        error
+}
+
+func (out *valueWriter) write(input []byte) {
+	if out.err != nil {
+		return
+	}
+	if !out.compact {
+		out.writeOut(input)
+		return
+	}
+	buf := out.scratch[:0]
+	for _, c := range input {
+		if out.inString {
+			buf = append(buf, c)
+			if out.escaped {
+				out.escaped = false
+			} else if c == '\\' {
+				out.escaped = true
+			} else if c == '"' {
+				out.inString = false
+			}
+			continue
+		}
+		switch c {
+		case ' ', '\t', '\n', '\r':
+			continue
+		}
+		if !out.indenting {
+			buf = append(buf, c)
+			out.inString = c == '"'
+			continue
+		}
+		if out.needIndent && c != ']' && c != '}' {
+			out.needIndent = false
+			buf = out.appendNewLine(buf)
+		}
+		switch c {
+		case '{', '[':
+			buf = append(buf, c)
+			out.depth++
+			out.needIndent = true
+		case '}', ']':
+			out.depth--
+			if out.needIndent {
+				out.needIndent = false
+			} else {
+				buf = out.appendNewLine(buf)
+			}
+			buf = append(buf, c)
+		case ',':
+			buf = append(buf, c)
+			buf = out.appendNewLine(buf)
+		case ':':
+			buf = append(buf, c, ' ')
+		default:
+			buf = append(buf, c)
+			out.inString = c == '"'
+		}
+	}
+	out.scratch = buf
+	out.writeOut(buf)
+}
+
+func (out *valueWriter) appendNewLine(buf []byte) []byte {
+	buf = append(buf, '\n')
+	buf = append(buf, out.prefix...)
+	return append(buf, strings.Repeat(out.indent, out.depth)...)
+}
+
+func (out *valueWriter) writeOut(buf []byte) {
+	if len(buf) == 0 {
+		return
+	}
+	n, err := out.writer.Write(buf)
+	out.written += int64(n)
+	out.err = err
+}
@@ -0,0 +1,85 @@
+package jsoniter
+
+import "fmt"
+
+// DuplicateKeyPolicy tells how the keys repeated in one object are handled
+type DuplicateKeyPolicy int
+
+const (
+	// DuplicateKeysDefault is the behavior without policy,
+	// the last value is decoded into struct, map and interface{}, and the first one is found by Get
+	DuplicateKeysDefault DuplicateKeyPolicy = iota
+	// DuplicateKeysError rejects the object with duplicate keys
+	DuplicateKeysError
+	// DuplicateKeysFirstWins keeps the value of the first key, the values of the later ones are skipped
+	DuplicateKeysFirstWins
+	// DuplicateKeysLastWins keeps the value of the last key
+	DuplicateKeysLastWins
+)
+
+// objectKeys records the keys seen in one object to apply the DuplicateKeyPolicy
+type objectKeys struct {
+	policy DuplicateKeyPolicy
+	seen   map[interface{}]struct{}
+}
+
+func (iter *Iterator) newObjectKeys() objectKeys {
+	return objectKeys{policy: iter.cfg.duplicateKeys}
+}
+
+// duplicate records key, and tells if it is seen before in the object
+func (keys *objectKeys) duplicate(key interface{}) bool {
+	if keys.policy == DuplicateKeysDefault {
+		return false
+	}
+	if keys.seen == nil {
+		keys.seen = map[interface{}]struct{}{}
+	}
+	if _, found := keys.seen[key]; found {
+		return true
+	}
+	keys.seen[key] = struct{}{}
+	return false
+}
+
+// skip records key, and tells if the value of key should be skipped.
+// The duplicate key is reported as error by DuplicateKeysError, the value should be skipped as well.
+func (keys *objectKeys) skip(iter *Iterator, key interface{}) bool {
+	if !keys.duplicate(key) {
+		return false
+	}
+	switch keys.policy {
+	case DuplicateKeysError:
+		iter.ReportError("ReadObject", duplicateKeyMessage(key))
+		return true
+	case DuplicateKeysFirstWins:
+		return true
+	}
+	return false
+}
+
+func duplicateKeyMessage(key interface{}) string {
+	return fmt.Sprintf("found duplicate key: %v", key)
+}
+
+// skipCheckingKeys is Skip rejecting the objects with duplicate keys, for Valid by DuplicateKeysError
+func (iter *Iterator) skipCheckingKeys() {
+	switch iter.WhatIsNext() {
+	case ObjectValue:
+		keys := iter.newObjectKeys()
+		iter.ReadObjectCB(func(iter *Iterator, field string) bool {
+			if keys.skip(iter, field) {
+				return false
+			}
+			iter.skipCheckingKeys()
+			return true
+		})
+	case ArrayValue:
+		iter.ReadArrayCB(func(iter *Iterator) bool {
+			iter.skipCheckingKeys()
+			return true
+		})
+	default:
+		iter.Skip()
+	}
+}
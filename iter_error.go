@@ -0,0 +1,488 @@
+package jsoniter
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/modern-go/reflect2"
+)
+
+// DecodeError describes a problem found while decoding JSON.
+// It is the error reported by Iterator.ReportError, so that the failing position
+// can be inspected programmatically instead of parsing the error message.
+type DecodeError struct {
+	Operation string // the operation reporting the error, such as readUint64
+	Message   string
+	Offset    int64  // absolute byte offset of the last byte read when the error was found
+	Line      int    // 1-based line of Offset
+	Column    int    // 1-based byte column of Offset
+	Pointer   string // RFC 6901 JSON Pointer to the failing value, "" is the whole document
+	Err       error  // the underlying error, such as *LimitError or the error returned by io.Reader
+	prefix    string // decoding context added by the reflect decoders, such as "test.Message.Number: "
+	snippet   string
+	// to build json.SyntaxError or json.UnmarshalTypeError
+	endOfInput bool // the input ended before the error was found
+	depth      int  // the arrays and objects entered when the error was found
+	valueType  reflect.Type
+	structName string
+	fieldPath  []string
+}
+
+func (err *DecodeError) Error() string {
+	if err.Operation == "" {
+		return err.prefix + err.Err.Error()
+	}
+	return err.prefix + err.Operation + ": " + err.Message + ", " + err.snippet
+}
+
+// Unwrap returns the underlying error, if any
+func (err *DecodeError) Unwrap() error {
+	return err.Err
+}
+
+// position returns the absolute offset, line and column of the last byte read,
+// which is usually the offending byte when reporting errors
+func (iter *Iterator) position() (offset int64, line int, column int) {
+	head := iter.head - 1
+	if head > iter.tail {
+		head = iter.tail
+	}
+	if head < 0 {
+		head = 0
+	}
+	consumed := iter.buf[:head]
+	offset = iter.consumed + int64(head)
+	line = iter.consumedLines + bytes.Count(consumed, []byte{'\n'}) + 1
+	lastNewLine := bytes.LastIndexByte(consumed, '\n')
+	if lastNewLine == -1 {
+		column = iter.consumedColumn + head + 1
+	} else {
+		column = head - lastNewLine
+	}
+	return
+}
+
+// positionAfterDiscard calculates the position counters after loadMore discarding the current buffer
+func (iter *Iterator) positionAfterDiscard() (consumed int64, lines int, column int) {
+	discarded := iter.buf[:iter.tail]
+	consumed = iter.consumed + int64(iter.tail)
+	lastNewLine := bytes.LastIndexByte(discarded, '\n')
+	if lastNewLine == -1 {
+		return consumed, iter.consumedLines, iter.consumedColumn + iter.tail
+	}
+	lines = iter.consumedLines + bytes.Count(discarded, []byte{'\n'})
+	return consumed, lines, iter.tail - lastNewLine - 1
+}
+
+func (iter *Iterator) resetPosition() {
+	iter.consumed = 0
+	iter.consumedLines = 0
+	iter.consumedColumn = 0
+	iter.afterSeparator = false
+	iter.inputCut = false
+	iter.recording.marks = 0
+	iter.smallArrayEnd = 0
+}
+
+// reportUnderlyingError reports err as the error of operation, err is kept as DecodeError.Err
+func (iter *Iterator) reportUnderlyingError(operation string, err error) {
+	if iter.Error != nil && iter.Error != io.EOF {
+		return
+	}
+	iter.ReportError(operation, err.Error())
+	iter.asDecodeError().Err = err
+}
+
+// asDecodeError turns the current error into *DecodeError, so that context can be attached to it
+func (iter *Iterator) asDecodeError() *DecodeError {
+	decodeErr, isDecodeErr := iter.Error.(*DecodeError)
+	if isDecodeErr {
+		return decodeErr
+	}
+	decodeErr = &DecodeError{Err: iter.Error}
+	decodeErr.Offset, decodeErr.Line, decodeErr.Column = iter.position()
+	iter.Error = decodeErr
+	return decodeErr
+}
+
+// addErrorContext prefixes the current error message with prefix,
+// and prepends the reference tokens to the JSON pointer of the failing value.
+func (iter *Iterator) addErrorContext(prefix string, tokens ...string) {
+	if iter.Error == nil || iter.Error == io.EOF {
+		return
+	}
+	decodeErr := iter.asDecodeError()
+	decodeErr.prefix = prefix + decodeErr.prefix
+	decodeErr.addPointerTokens(tokens)
+}
+
+func (err *DecodeError) addPointerTokens(tokens []string) {
+	for i := len(tokens) - 1; i >= 0; i-- {
+		err.Pointer = "/" + escapePointerToken(tokens[i]) + err.Pointer
+	}
+	if len(tokens) > 0 {
+		err.fieldPath = append(tokens[:len(tokens):len(tokens)], err.fieldPath...)
+	}
+}
+
+var pointerTokenEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func escapePointerToken(token string) string {
+	if strings.IndexByte(token, '~') == -1 && strings.IndexByte(token, '/') == -1 {
+		return token
+	}
+	return pointerTokenEscaper.Replace(token)
+}
+
+// addErrorType records the innermost Go type being decoded when the error was found
+func (iter *Iterator) addErrorType(typ reflect2.Type) {
+	if iter.Error == nil || iter.Error == io.EOF {
+		return
+	}
+	decodeErr := iter.asDecodeError()
+	if decodeErr.valueType != nil {
+		return
+	}
+	valueType := typ.Type1()
+	for valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
+	}
+	decodeErr.valueType = valueType
+}
+
+// addStructErrorContext prefixes the current error with the struct type,
+// and remembers the outermost struct holding the failing field, same as encoding/json
+func (iter *Iterator) addStructErrorContext(typ reflect2.Type) {
+	if iter.Error == nil || iter.Error == io.EOF {
+		return
+	}
+	decodeErr := iter.asDecodeError()
+	decodeErr.prefix = typ.String() + "." + decodeErr.prefix
+	if len(decodeErr.fieldPath) > 0 {
+		decodeErr.structName = typ.Type1().Name()
+	}
+}
+
+// DecodeErrors is returned in CollectAllErrors mode, listing every value can not be decoded
+type DecodeErrors []*DecodeError
+
+func (errs DecodeErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		if err.Pointer == "" {
+			messages[i] = err.Error()
+		} else {
+			messages[i] = err.Pointer + ": " + err.Error()
+		}
+	}
+	if len(messages) == 1 {
+		return messages[0]
+	}
+	return strconv.Itoa(len(messages)) + " errors: " + strings.Join(messages, "; ")
+}
+
+// decodeMark remembers where the decoding of a value starts,
+// so that the value can be skipped if it turns out can not be decoded
+type decodeMark struct {
+	head      int
+	consumed  int64
+	collected int
+	failed    bool
+	recording bool // the mark holds the recording of the input read from reader
+}
+
+// valueRecording keeps the input discarded by loadMore while any value is marked in CollectAllErrors mode,
+// so that the value failed can be skipped from its start after the buffer is refilled
+type valueRecording struct {
+	marks    int    // the marks not released yet
+	recorded []byte // the input from the start of the buffer holding the outermost mark, to the current buffer
+	consumed int64  // the position of the first byte recorded
+	lines    int
+	column   int
+}
+
+func (iter *Iterator) mark() decodeMark {
+	mark := decodeMark{
+		head:      iter.head,
+		consumed:  iter.consumed,
+		collected: len(iter.collectedErrors),
+		failed:    iter.Error != nil && iter.Error != io.EOF,
+	}
+	if iter.cfg.collectAllErrors && iter.reader != nil {
+		if iter.recording.marks == 0 {
+			iter.recording = valueRecording{
+				recorded: iter.recording.recorded[:0],
+				consumed: iter.consumed,
+				lines:    iter.consumedLines,
+				column:   iter.consumedColumn,
+			}
+		}
+		iter.recording.marks++
+		mark.recording = true
+	}
+	return mark
+}
+
+func (iter *Iterator) releaseMark(mark decodeMark) {
+	if mark.recording {
+		iter.recording.marks--
+	}
+}
+
+// hasNewErrors tells if any error was found since mark.
+// The mark is released if not, otherwise by recoverValue.
+func (iter *Iterator) hasNewErrors(mark decodeMark) bool {
+	if iter.Error != nil && iter.Error != io.EOF || len(iter.collectedErrors) != mark.collected {
+		return true
+	}
+	iter.releaseMark(mark)
+	return false
+}
+
+// rewindRecording refills the buffer with the recorded input followed by the current buffer,
+// so that the positions of the marks can be rewound to
+func (iter *Iterator) rewindRecording() {
+	recording := &iter.recording
+	buf := make([]byte, 0, len(recording.recorded)+iter.tail)
+	buf = append(append(buf, recording.recorded...), iter.buf[:iter.tail]...)
+	iter.buf, iter.tail = buf, len(buf)
+	iter.consumed, iter.consumedLines, iter.consumedColumn = recording.consumed, recording.lines, recording.column
+	recording.recorded = recording.recorded[:0]
+}
+
+// recoverValue attaches the context to the errors found in the value decoded since mark.
+// In CollectAllErrors mode, the value can not be stored into typ is skipped and the error is collected,
+// unless the value is malformed. It returns false if the decoding should stop.
+func (iter *Iterator) recoverValue(mark decodeMark, typ reflect2.Type, prefix string, tokens ...string) bool {
+	defer iter.releaseMark(mark)
+	if mark.failed {
+		return false
+	}
+	for _, collected := range iter.collectedErrors[mark.collected:] {
+		collected.addPointerTokens(tokens)
+	}
+	if iter.Error == nil || iter.Error == io.EOF {
+		return true
+	}
+	iter.addErrorType(typ)
+	iter.addErrorContext(prefix, tokens...)
+	if !iter.cfg.collectAllErrors || isLimitError(iter.Error) || isContextError(iter.Error) {
+		return false
+	}
+	decodeErr := iter.asDecodeError()
+	iter.Error = nil
+	if iter.consumed != mark.consumed {
+		iter.rewindRecording()
+	}
+	iter.head = int(mark.consumed + int64(mark.head) - iter.consumed)
+	iter.Skip()
+	if iter.Error != nil && iter.Error != io.EOF {
+		iter.Error = decodeErr
+		return false
+	}
+	iter.collectedErrors = append(iter.collectedErrors, decodeErr)
+	return true
+}
+
+// collectedError returns the collected errors, followed by the error stopped the decoding
+func (iter *Iterator) collectedError() error {
+	errs := DecodeErrors(iter.collectedErrors)
+	if iter.Error != nil && iter.Error != io.EOF {
+		errs = append(errs, iter.asDecodeError())
+	}
+	iter.collectedErrors = nil
+	return errs
+}
+
+// checkUnmarshalTarget reports json.InvalidUnmarshalError for the target can not be unmarshalled into
+func checkUnmarshalTarget(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return &json.InvalidUnmarshalError{Type: reflect.TypeOf(v)}
+	}
+	return nil
+}
+
+// standardLibraryError converts the decoding error into *json.SyntaxError or *json.UnmarshalTypeError,
+// if the config asks for errors compatible with standard library.
+// The error is told from what the iterator recorded when reporting it, the input is not parsed again.
+// base is the offset of the value being decoded, UnmarshalTypeError.Offset is relative to it, the same as json.Decoder.
+// Same as standard library, the value of the wrong type is skipped with the arrays and objects around it,
+// so that a syntax error after it wins. The iterator is then left after the whole value, without error.
+func (iter *Iterator) standardLibraryError(base int64) error {
+	err := iter.Error
+	if !iter.cfg.standardLibraryErrors {
+		return err
+	}
+	decodeErr, isDecodeErr := err.(*DecodeError)
+	if !isDecodeErr || isLimitError(err) || isContextError(err) {
+		return err
+	}
+	if decodeErr.Err != nil {
+		// the error returned by UnmarshalJSON or UnmarshalText is returned as is, the same as standard library
+		return decodeErr.Err
+	}
+	start, isTypeErr := iter.mismatchedValue(decodeErr)
+	if !isTypeErr {
+		return iter.syntaxError(decodeErr)
+	}
+	iter.Error = nil
+	iter.head = start
+	offset := iter.consumed + int64(start) + 1 // after the open bracket of array or object
+	value := iter.SkipAndReturnBytes()
+	if iter.Error == nil || iter.Error == io.EOF {
+		if value[0] != '[' && value[0] != '{' {
+			offset = iter.consumed + int64(iter.head)
+		}
+		iter.skipContainers(decodeErr.depth - iter.depth)
+	}
+	if iter.Error != nil && iter.Error != io.EOF {
+		return iter.syntaxError(iter.Error)
+	}
+	valueType := decodeErr.valueType
+	if valueType == nil {
+		valueType = reflect.TypeOf((*interface{})(nil)).Elem()
+	}
+	return &json.UnmarshalTypeError{
+		Value:  describeValue(value, decodeErr.valueType),
+		Type:   valueType,
+		Offset: offset - base,
+		Struct: decodeErr.structName,
+		Field:  strings.Join(decodeErr.fieldPath, "."),
+	}
+}
+
+// mismatchedValue tells if the error is found at a value, which is not a syntax error if the value is valid.
+// It returns where the value starts in the buffer.
+func (iter *Iterator) mismatchedValue(decodeErr *DecodeError) (int, bool) {
+	start := int(decodeErr.Offset - iter.consumed)
+	if start < 0 || start >= iter.tail || syntaxErrorContext(decodeErr.Message) != "" {
+		return 0, false
+	}
+	// the error may be found in the middle of a number, rewind to its beginning
+	for start > 0 && isNumberByte(iter.buf[start]) && isNumberByte(iter.buf[start-1]) {
+		start--
+	}
+	return start, valueTypes[iter.buf[start]] != InvalidValue
+}
+
+// skipContainers skips the rest of the arrays and objects entered, to the end of the value being decoded
+func (iter *Iterator) skipContainers(depth int) {
+	for ; depth > 0 && (iter.Error == nil || iter.Error == io.EOF); depth-- {
+		c := iter.nextToken()
+		for c == ',' || c == ':' {
+			iter.Skip()
+			c = iter.nextToken()
+		}
+		if c != ']' && c != '}' {
+			iter.ReportError("skipContainers", "expect , or ] or }, but found "+string([]byte{c}))
+		}
+	}
+}
+
+// syntaxError builds json.SyntaxError from the error found in the input
+func (iter *Iterator) syntaxError(err error) error {
+	decodeErr, isDecodeErr := err.(*DecodeError)
+	if !isDecodeErr || decodeErr.Err != nil {
+		return err
+	}
+	if decodeErr.endOfInput {
+		return newSyntaxError("unexpected end of JSON input", iter.consumed+int64(iter.tail))
+	}
+	found := int(decodeErr.Offset - iter.consumed)
+	if found < 0 || found >= iter.tail {
+		return newSyntaxError(decodeErr.Message, decodeErr.Offset+1)
+	}
+	context := syntaxErrorContext(decodeErr.Message)
+	if context == "" {
+		context = "looking for beginning of value"
+	}
+	return newSyntaxError("invalid character "+quoteChar(iter.buf[found])+" "+context, decodeErr.Offset+1)
+}
+
+// syntaxErrorContexts tells what was expected from the message of the iterator, in the words of json.SyntaxError
+var syntaxErrorContexts = []struct {
+	prefix  string
+	context string
+}{
+	{"there are bytes left", "after top-level value"},
+	{"expect : after", "after object key"},
+	{"expect :,", "after object key"},
+	{`expect " after`, "looking for beginning of object key string"},
+	{`expect ", but`, "looking for beginning of object key string"},
+	{"expect }", "after object key:value pair"},
+	{"object not ended", "after object key:value pair"},
+	{"object ended prematurely", "after object key:value pair"},
+	{"expect ]", "after array element"},
+	{"expect , or ]", "after array element"},
+	{"expect , after array element", "after array element"},
+}
+
+func syntaxErrorContext(msg string) string {
+	for _, syntaxErrorContext := range syntaxErrorContexts {
+		if strings.HasPrefix(msg, syntaxErrorContext.prefix) {
+			return syntaxErrorContext.context
+		}
+	}
+	return ""
+}
+
+// syntaxError has the same layout as json.SyntaxError, whose message can not be set out of encoding/json
+type syntaxError struct {
+	msg    string
+	Offset int64
+}
+
+func newSyntaxError(msg string, offset int64) error {
+	return (*json.SyntaxError)(unsafe.Pointer(&syntaxError{msg: msg, Offset: offset}))
+}
+
+// quoteChar formats c the same way as json.SyntaxError
+func quoteChar(c byte) string {
+	if c == '\'' {
+		return `'\''`
+	}
+	if c == '"' {
+		return `'"'`
+	}
+	quoted := strconv.Quote(string(rune(c)))
+	return "'" + quoted[1:len(quoted)-1] + "'"
+}
+
+// describeValue describes the JSON value the same way as json.UnmarshalTypeError
+func describeValue(value []byte, target reflect.Type) string {
+	switch value[0] {
+	case '"':
+		return "string"
+	case 't', 'f':
+		return "bool"
+	case 'n':
+		return "null"
+	case '[':
+		return "array"
+	case '{':
+		return "object"
+	}
+	if target != nil {
+		switch target.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+			reflect.Float32, reflect.Float64:
+			return "number " + string(value)
+		}
+	}
+	return "number"
+}
+
+func isNumberByte(c byte) bool {
+	switch c {
+	case '+', '-', '.', 'e', 'E', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,350 @@
+package jsoniter
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"sync"
+	"unsafe"
+)
+
+// The floats are parsed in place from the buffer of iterator:
+// the decimal mantissa and exponent are scanned without copying,
+// then converted exactly by float arithmetic if both are small (Clinger's fast path),
+// or by the Eisel-Lemire algorithm, which is correctly rounded or tells it can not decide.
+// strconv.ParseFloat is only used for the undecided and out of range numbers.
+
+// maxMantissaDigits is the significant digits fit in uint64
+const maxMantissaDigits = 19
+
+const (
+	minExp10OfPowers = -348
+	maxExp10OfPowers = 347
+)
+
+// detailedPowersOfTen holds the 128-bit mantissa of 10^q rounded down, as {low, high} uint64
+var detailedPowersOfTen [maxExp10OfPowers - minExp10OfPowers + 1][2]uint64
+var detailedPowersOfTenOnce sync.Once
+
+func initDetailedPowersOfTen() {
+	ten := big.NewInt(10)
+	mask64 := new(big.Int).SetUint64(math.MaxUint64)
+	for q := minExp10OfPowers; q <= maxExp10OfPowers; q++ {
+		mantissa := new(big.Int)
+		if q >= 0 {
+			mantissa.Exp(ten, big.NewInt(int64(q)), nil)
+			if shift := mantissa.BitLen() - 128; shift > 0 {
+				mantissa.Rsh(mantissa, uint(shift))
+			} else {
+				mantissa.Lsh(mantissa, uint(-shift))
+			}
+		} else {
+			divisor := new(big.Int).Exp(ten, big.NewInt(int64(-q)), nil)
+			// 2^shift / 10^-q has 128 bits
+			shift := divisor.BitLen() + 127
+			mantissa.Lsh(big.NewInt(1), uint(shift))
+			mantissa.Quo(mantissa, divisor)
+		}
+		entry := &detailedPowersOfTen[q-minExp10OfPowers]
+		entry[0] = new(big.Int).And(mantissa, mask64).Uint64()
+		entry[1] = new(big.Int).Rsh(mantissa, 64).Uint64()
+	}
+}
+
+var float64Pow10 = [...]float64{1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9,
+	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19, 1e20, 1e21, 1e22}
+var float32Pow10 = [...]float32{1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10}
+
+const (
+	floatScanned = iota
+	floatNeedsSlowPath
+	floatInvalid
+)
+
+// scannedFloat is a positive decimal number scanned in the buffer of iterator
+type scannedFloat struct {
+	mantissa  uint64 // the first 19 significant digits
+	exp10     int    // the value is mantissa * 10^exp10
+	truncated bool   // there are more non-zero digits after the first 19
+	end       int    // the index of buffer after the number
+}
+
+// scanFloat scans the positive number at head without copying.
+// The number not ended in the buffer, or not in the plain form, needs the slow path to be parsed and validated,
+// only the invalid leading characters are reported here.
+func (iter *Iterator) scanFloat(operation string) (number scannedFloat, status int) {
+	i := iter.head
+	if i == iter.tail {
+		return number, floatNeedsSlowPath
+	}
+	switch floatDigits[iter.buf[i]] {
+	case invalidCharForNumber:
+		return number, floatNeedsSlowPath
+	case endOfNumber:
+		iter.ReportError(operation, "empty number")
+		return number, floatInvalid
+	case dotInNumber:
+		iter.ReportError(operation, "leading dot is invalid")
+		return number, floatInvalid
+	case 0:
+		if i+1 < iter.tail && floatDigits[iter.buf[i+1]] >= 0 {
+			iter.ReportError(operation, "leading zero is invalid")
+			return number, floatInvalid
+		}
+	}
+	digits, mantissaDigits, decimalPoint := 0, 0, 0
+	sawDot := false
+digits_loop:
+	for ; i < iter.tail; i++ {
+		c := iter.buf[i]
+		switch {
+		case c >= '0' && c <= '9':
+			if c == '0' && digits == 0 {
+				// leading zeros are not significant
+				decimalPoint--
+				continue
+			}
+			digits++
+			if mantissaDigits < maxMantissaDigits {
+				number.mantissa = number.mantissa*10 + uint64(c-'0')
+				mantissaDigits++
+			} else if c != '0' {
+				number.truncated = true
+			}
+		case c == '.':
+			if sawDot || i+1 == iter.tail || iter.buf[i+1] < '0' || iter.buf[i+1] > '9' {
+				return number, floatNeedsSlowPath
+			}
+			sawDot = true
+			decimalPoint = digits
+		default:
+			break digits_loop
+		}
+	}
+	if !sawDot {
+		decimalPoint = digits
+	}
+	if i < iter.tail && (iter.buf[i] == 'e' || iter.buf[i] == 'E') {
+		i++
+		sign := 1
+		if i < iter.tail && (iter.buf[i] == '+' || iter.buf[i] == '-') {
+			if iter.buf[i] == '-' {
+				sign = -1
+			}
+			i++
+		}
+		if i == iter.tail || iter.buf[i] < '0' || iter.buf[i] > '9' {
+			return number, floatNeedsSlowPath
+		}
+		exp := 0
+		for ; i < iter.tail && iter.buf[i] >= '0' && iter.buf[i] <= '9'; i++ {
+			if exp < 10000 {
+				exp = exp*10 + int(iter.buf[i]-'0')
+			}
+		}
+		decimalPoint += exp * sign
+	}
+	if i == iter.tail {
+		if iter.reader != nil {
+			// the number may continue in the next read
+			return number, floatNeedsSlowPath
+		}
+	} else if floatDigits[iter.buf[i]] != endOfNumber {
+		return number, floatNeedsSlowPath
+	}
+	if number.mantissa != 0 {
+		number.exp10 = decimalPoint - mantissaDigits
+	}
+	number.end = i
+	return number, floatScanned
+}
+
+// toFloat64 converts the number correctly rounded, it returns false if it can not be decided
+func (number *scannedFloat) toFloat64() (float64, bool) {
+	if !number.truncated {
+		if f, ok := exactFloat64(number.mantissa, number.exp10); ok {
+			return f, true
+		}
+	}
+	f, ok := eiselLemire64(number.mantissa, number.exp10)
+	if ok && number.truncated {
+		// the digits dropped are between mantissa and mantissa+1
+		upper, upperOk := eiselLemire64(number.mantissa+1, number.exp10)
+		ok = upperOk && upper == f
+	}
+	return f, ok
+}
+
+// toFloat32 converts the number correctly rounded, it returns false if it can not be decided
+func (number *scannedFloat) toFloat32() (float32, bool) {
+	if !number.truncated {
+		if f, ok := exactFloat32(number.mantissa, number.exp10); ok {
+			return f, true
+		}
+	}
+	f, ok := eiselLemire32(number.mantissa, number.exp10)
+	if ok && number.truncated {
+		upper, upperOk := eiselLemire32(number.mantissa+1, number.exp10)
+		ok = upperOk && upper == f
+	}
+	return f, ok
+}
+
+// bytesAsString is the number in buffer as string for strconv, without copying
+func (iter *Iterator) bytesAsString(end int) string {
+	bytes := iter.buf[iter.head:end]
+	return *(*string)(unsafe.Pointer(&bytes))
+}
+
+// exactFloat64 converts by float64 arithmetic if both mantissa and 10^exp10 are exact
+func exactFloat64(mantissa uint64, exp10 int) (float64, bool) {
+	if mantissa>>53 != 0 {
+		return 0, false
+	}
+	f := float64(mantissa)
+	switch {
+	case exp10 == 0:
+		return f, true
+	case exp10 > 0 && exp10 <= 15+22:
+		if exp10 > 22 {
+			// move the zeros to mantissa, if it is still exact
+			f *= float64Pow10[exp10-22]
+			exp10 = 22
+		}
+		if f > 1e15 {
+			return 0, false
+		}
+		return f * float64Pow10[exp10], true
+	case exp10 < 0 && exp10 >= -22:
+		return f / float64Pow10[-exp10], true
+	}
+	return 0, false
+}
+
+// exactFloat32 converts by float32 arithmetic if both mantissa and 10^exp10 are exact
+func exactFloat32(mantissa uint64, exp10 int) (float32, bool) {
+	if mantissa>>24 != 0 {
+		return 0, false
+	}
+	f := float32(mantissa)
+	switch {
+	case exp10 == 0:
+		return f, true
+	case exp10 > 0 && exp10 <= 7+10:
+		if exp10 > 10 {
+			f *= float32Pow10[exp10-10]
+			exp10 = 10
+		}
+		if f > 1e7 {
+			return 0, false
+		}
+		return f * float32Pow10[exp10], true
+	case exp10 < 0 && exp10 >= -10:
+		return f / float32Pow10[-exp10], true
+	}
+	return 0, false
+}
+
+// eiselLemire64 is mantissa * 10^exp10 as float64,
+// it returns false if the result can not be decided or is out of the range of normal float64
+func eiselLemire64(mantissa uint64, exp10 int) (float64, bool) {
+	if mantissa == 0 {
+		return 0, true
+	}
+	if exp10 < minExp10OfPowers || exp10 > maxExp10OfPowers {
+		return 0, false
+	}
+	detailedPowersOfTenOnce.Do(initDetailedPowersOfTen)
+	power := &detailedPowersOfTen[exp10-minExp10OfPowers]
+	// normalize, the highest bit of mantissa is 1
+	clz := bits.LeadingZeros64(mantissa)
+	mantissa <<= uint(clz)
+	// 217706 / 2^16 approximates log2(10)
+	retExp2 := uint64(217706*exp10>>16+64+1023) - uint64(clz)
+	xHi, xLo := bits.Mul64(mantissa, power[1])
+	if xHi&0x1FF == 0x1FF && xLo+mantissa < mantissa {
+		// the lower bits may carry, use the wider approximation of the power
+		yHi, yLo := bits.Mul64(mantissa, power[0])
+		mergedHi, mergedLo := xHi, xLo+yHi
+		if mergedLo < xLo {
+			mergedHi++
+		}
+		if mergedHi&0x1FF == 0x1FF && mergedLo+1 == 0 && yLo+mantissa < mantissa {
+			return 0, false
+		}
+		xHi, xLo = mergedHi, mergedLo
+	}
+	// shift to 54 bits
+	msb := xHi >> 63
+	retMantissa := xHi >> (msb + 9)
+	retExp2 -= 1 ^ msb
+	// half-way between two floats, which can not be decided by the approximation
+	if xLo == 0 && xHi&0x1FF == 0 && retMantissa&3 == 1 {
+		return 0, false
+	}
+	// round to 53 bits
+	retMantissa += retMantissa & 1
+	retMantissa >>= 1
+	if retMantissa>>53 > 0 {
+		retMantissa >>= 1
+		retExp2++
+	}
+	// subnormal, infinity or NaN
+	if retExp2-1 >= 0x7FF-1 {
+		return 0, false
+	}
+	return math.Float64frombits(retExp2<<52 | retMantissa&(1<<52-1)), true
+}
+
+// eiselLemire32 is mantissa * 10^exp10 as float32,
+// it returns false if the result can not be decided or is out of the range of normal float32
+func eiselLemire32(mantissa uint64, exp10 int) (float32, bool) {
+	if mantissa == 0 {
+		return 0, true
+	}
+	if exp10 < minExp10OfPowers || exp10 > maxExp10OfPowers {
+		return 0, false
+	}
+	detailedPowersOfTenOnce.Do(initDetailedPowersOfTen)
+	power := &detailedPowersOfTen[exp10-minExp10OfPowers]
+	clz := bits.LeadingZeros64(mantissa)
+	mantissa <<= uint(clz)
+	retExp2 := uint64(217706*exp10>>16+64+127) - uint64(clz)
+	xHi, xLo := bits.Mul64(mantissa, power[1])
+	if xHi&0x3FFFFFFFFF == 0x3FFFFFFFFF && xLo+mantissa < mantissa {
+		yHi, yLo := bits.Mul64(mantissa, power[0])
+		mergedHi, mergedLo := xHi, xLo+yHi
+		if mergedLo < xLo {
+			mergedHi++
+		}
+		if mergedHi&0x3FFFFFFFFF == 0x3FFFFFFFFF && mergedLo+1 == 0 && yLo+mantissa < mantissa {
+			return 0, false
+		}
+		xHi, xLo = mergedHi, mergedLo
+	}
+	// shift to 25 bits
+	msb := xHi >> 63
+	retMantissa := xHi >> (msb + 38)
+	retExp2 -= 1 ^ msb
+	if xLo == 0 && xHi&0x3FFFFFFFFF == 0 && retMantissa&3 == 1 {
+		return 0, false
+	}
+	// round to 24 bits
+	retMantissa += retMantissa & 1
+	retMantissa >>= 1
+	if retMantissa>>24 > 0 {
+		retMantissa >>= 1
+		retExp2++
+	}
+	if retExp2-1 >= 0xFF-1 {
+		return 0, false
+	}
+	return math.Float32frombits(uint32(retExp2<<23 | retMantissa&(1<<23-1))), true
+}
+
+// parseFloatInPlace is strconv.ParseFloat of the number in buffer, it returns false if strconv fails
+func (iter *Iterator) parseFloatInPlace(end int, bitSize int) (float64, bool) {
+	f, err := strconv.ParseFloat(iter.bytesAsString(end), bitSize)
+	return f, err == nil
+}
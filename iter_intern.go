@@ -0,0 +1,51 @@
+package jsoniter
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+// maxInternedLength is the max bytes of the strings interned, the long strings are rarely repeated
+const maxInternedLength = 64
+
+// stringInterner is a fixed size table of strings decoded before, shared by the iterators of a frozen config.
+// The slot is selected by the hash of the bytes, and the string in the slot is replaced on collision,
+// so the table never holds more strings than its size. It is safe for concurrent use without locks.
+type stringInterner struct {
+	slots []unsafe.Pointer // *string
+	mask  uint32
+}
+
+func newStringInterner(size int) *stringInterner {
+	if size <= 0 {
+		return nil
+	}
+	slotsCount := 1
+	for slotsCount < size && slotsCount < 1<<30 {
+		slotsCount <<= 1
+	}
+	return &stringInterner{
+		slots: make([]unsafe.Pointer, slotsCount),
+		mask:  uint32(slotsCount - 1),
+	}
+}
+
+// intern returns the string of bytes, reusing the string decoded before if it is in the table
+func (interner *stringInterner) intern(bytes []byte) string {
+	if len(bytes) > maxInternedLength {
+		return string(bytes)
+	}
+	// FNV-1a
+	hash := uint32(2166136261)
+	for _, b := range bytes {
+		hash ^= uint32(b)
+		hash *= 16777619
+	}
+	slot := &interner.slots[hash&interner.mask]
+	if interned := (*string)(atomic.LoadPointer(slot)); interned != nil && *interned == string(bytes) {
+		return *interned
+	}
+	str := string(bytes)
+	atomic.StorePointer(slot, unsafe.Pointer(&str))
+	return str
+}
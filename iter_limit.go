@@ -0,0 +1,108 @@
+package jsoniter
+
+import (
+	"fmt"
+	"io"
+)
+
+const maxInt = int(^uint(0) >> 1)
+
+// LimitError is the underlying error of DecodeError,
+// when the input exceeds one of the limits set in Config, such as MaxDepth
+type LimitError struct {
+	Limit string // name of the Config field, such as MaxDepth
+	Max   int64
+}
+
+func (err *LimitError) Error() string {
+	return fmt.Sprintf("%s of %d exceeded", err.Limit, err.Max)
+}
+
+// limitOrMax turns the limit set in Config into the value to compare with, 0 means no limit
+func limitOrMax(limit int) int {
+	if limit <= 0 {
+		return maxInt
+	}
+	return limit
+}
+
+func (iter *Iterator) reportLimitError(operation string, limit string, max int) {
+	if iter.Error != nil && iter.Error != io.EOF {
+		return
+	}
+	limitErr := &LimitError{Limit: limit, Max: int64(max)}
+	iter.Error = nil
+	iter.ReportError(operation, limitErr.Error())
+	iter.asDecodeError().Err = limitErr
+}
+
+func isLimitError(err error) bool {
+	decodeErr, isDecodeErr := err.(*DecodeError)
+	if !isDecodeErr {
+		return false
+	}
+	_, isLimitErr := decodeErr.Err.(*LimitError)
+	return isLimitErr
+}
+
+// incrementDepth enters an array or object, it reports error if MaxDepth is exceeded
+func (iter *Iterator) incrementDepth() bool {
+	iter.depth++
+	if iter.depth > iter.cfg.maxDepth {
+		iter.depth--
+		iter.reportLimitError("incrementDepth", "MaxDepth", iter.cfg.maxDepth)
+		return false
+	}
+	if !iter.checkContext("incrementDepth") {
+		iter.depth--
+		return false
+	}
+	return true
+}
+
+// decrementDepth leaves an array or object entered by incrementDepth
+func (iter *Iterator) decrementDepth() {
+	iter.depth--
+}
+
+func (iter *Iterator) checkStringLength(length int) bool {
+	if length > iter.cfg.maxStringLength {
+		iter.reportLimitError("checkStringLength", "MaxStringLength", iter.cfg.maxStringLength)
+		return false
+	}
+	return true
+}
+
+func (iter *Iterator) checkArrayElements(count int) bool {
+	if count > iter.cfg.maxArrayElements {
+		iter.reportLimitError("checkArrayElements", "MaxArrayElements", iter.cfg.maxArrayElements)
+		return false
+	}
+	return iter.checkContext("checkArrayElements")
+}
+
+func (iter *Iterator) checkObjectMembers(count int) bool {
+	if count > iter.cfg.maxObjectMembers {
+		iter.reportLimitError("checkObjectMembers", "MaxObjectMembers", iter.cfg.maxObjectMembers)
+		return false
+	}
+	return iter.checkContext("checkObjectMembers")
+}
+
+// limitInputBytes cuts the buffer loaded at MaxInputBytes of the total input,
+// the input read ahead is not counted until the iterator reads past the limit
+func (iter *Iterator) limitInputBytes() {
+	if remaining := int64(iter.cfg.maxInputBytes) - iter.consumed; int64(iter.tail) > remaining {
+		iter.tail = int(remaining)
+		iter.inputCut = true
+	}
+}
+
+// checkInputBytes reports error if reading past the tail cut by limitInputBytes
+func (iter *Iterator) checkInputBytes() bool {
+	if iter.inputCut {
+		iter.reportLimitError("checkInputBytes", "MaxInputBytes", iter.cfg.maxInputBytes)
+		return false
+	}
+	return true
+}
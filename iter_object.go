@@ -16,9 +16,9 @@ func (iter *Iterator) ReadObject() (ret string) {
 		return "" // null
 	case '{':
 		c = iter.nextToken()
-		if c == '"' {
+		if c == '"' || c != '}' && iter.cfg.relaxed {
 			iter.unreadByte()
-			field := iter.ReadString()
+			field := iter.readObjectKey()
 			c = iter.nextToken()
 			if c != ':' {
 				iter.ReportError("ReadObject", "expect : after object field, but found "+string([]byte{c}))
@@ -31,7 +31,7 @@ func (iter *Iterator) ReadObject() (ret string) {
 		iter.ReportError("ReadObject", `expect " after {, but found `+string([]byte{c}))
 		return
 	case ',':
-		field := iter.ReadString()
+		field := iter.readObjectKey()
 		c = iter.nextToken()
 		if c != ':' {
 			iter.ReportError("ReadObject", "expect : after object field, but found "+string([]byte{c}))
@@ -50,6 +50,10 @@ func (iter *Iterator) readFieldHash() int64 {
 	hash := int64(0x811c9dc5)
 	c := iter.nextToken()
 	if c != '"' {
+		if iter.cfg.relaxed {
+			iter.unreadByte()
+			return iter.readRelaxedFieldHash()
+		}
 		iter.ReportError("readFieldHash", `expect ", but found `+string([]byte{c}))
 		return 0
 	}
@@ -95,6 +99,17 @@ func (iter *Iterator) readFieldHash() int64 {
 	}
 }
 
+// readRelaxedFieldHash reads the single-quoted or unquoted field name in relaxed mode
+func (iter *Iterator) readRelaxedFieldHash() int64 {
+	field := iter.readObjectKey()
+	c := iter.nextToken()
+	if c != ':' {
+		iter.ReportError("readFieldHash", `expect :, but found `+string([]byte{c}))
+		return 0
+	}
+	return calcHash(field, iter.cfg.caseSensitive)
+}
+
 func calcHash(str string, caseSensitive bool) int64 {
 	if !caseSensitive {
 		str = strings.ToLower(str)
@@ -112,10 +127,14 @@ func (iter *Iterator) ReadObjectCB(callback func(*Iterator, string) bool) bool {
 	c := iter.nextToken()
 	var field string
 	if c == '{' {
+		if !iter.incrementDepth() {
+			return false
+		}
+		defer iter.decrementDepth()
 		c = iter.nextToken()
-		if c == '"' {
+		if c == '"' || c != '}' && iter.cfg.relaxed {
 			iter.unreadByte()
-			field = iter.ReadString()
+			field = iter.readObjectKey()
 			c = iter.nextToken()
 			if c != ':' {
 				iter.ReportError("ReadObject", "expect : after object field, but found "+string([]byte{c}))
@@ -124,8 +143,11 @@ func (iter *Iterator) ReadObjectCB(callback func(*Iterator, string) bool) bool {
 				return false
 			}
 			c = iter.nextToken()
-			for c == ',' {
-				field = iter.ReadString()
+			for members := 2; c == ','; members++ {
+				if !iter.checkObjectMembers(members) {
+					return false
+				}
+				field = iter.readObjectKey()
 				c = iter.nextToken()
 				if c != ':' {
 					iter.ReportError("ReadObject", "expect : after object field, but found "+string([]byte{c}))
@@ -159,10 +181,14 @@ func (iter *Iterator) ReadObjectCB(callback func(*Iterator, string) bool) bool {
 func (iter *Iterator) ReadMapCB(callback func(*Iterator, string) bool) bool {
 	c := iter.nextToken()
 	if c == '{' {
+		if !iter.incrementDepth() {
+			return false
+		}
+		defer iter.decrementDepth()
 		c = iter.nextToken()
-		if c == '"' {
+		if c == '"' || c != '}' && iter.cfg.relaxed {
 			iter.unreadByte()
-			field := iter.ReadString()
+			field := iter.readObjectKey()
 			if iter.nextToken() != ':' {
 				iter.ReportError("ReadMapCB", "expect : after object field, but found "+string([]byte{c}))
 				return false
@@ -171,8 +197,11 @@ func (iter *Iterator) ReadMapCB(callback func(*Iterator, string) bool) bool {
 				return false
 			}
 			c = iter.nextToken()
-			for c == ',' {
-				field = iter.ReadString()
+			for members := 2; c == ','; members++ {
+				if !iter.checkObjectMembers(members) {
+					return false
+				}
+				field = iter.readObjectKey()
 				if iter.nextToken() != ':' {
 					iter.ReportError("ReadMapCB", "expect : after object field, but found "+string([]byte{c}))
 					return false
@@ -202,11 +231,17 @@ func (iter *Iterator) ReadMapCB(callback func(*Iterator, string) bool) bool {
 	return false
 }
 
+// readObjectStart tells if there are fields to read,
+// the caller should call decrementDepth after reading them
 func (iter *Iterator) readObjectStart() bool {
 	c := iter.nextToken()
 	if c == '{' {
+		if !iter.incrementDepth() {
+			return false
+		}
 		c = iter.nextToken()
 		if c == '}' {
+			iter.decrementDepth()
 			return false
 		}
 		iter.unreadByte()
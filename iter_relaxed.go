@@ -0,0 +1,157 @@
+package jsoniter
+
+import (
+	"fmt"
+)
+
+// nextRelaxedToken continues nextToken in relaxed mode, c is the ',' or '/' just read.
+// Comments are skipped like whitespaces, and the comma after an element or member is dropped before ] or }.
+func (iter *Iterator) nextRelaxedToken(c byte) byte {
+	c = iter.skipComments(c)
+	if c == ',' {
+		next := iter.skipComments(iter.nextNonWhitespace())
+		if next == ']' || next == '}' {
+			if iter.afterSeparator {
+				iter.ReportError("nextToken", "unexpected , before "+string([]byte{next}))
+			}
+			c = next
+		} else {
+			iter.unreadByte()
+		}
+	}
+	iter.afterSeparator = c == ',' || c == '[' || c == '{'
+	return c
+}
+
+// nextNonWhitespace is nextToken without the relaxed mode handling
+func (iter *Iterator) nextNonWhitespace() byte {
+	for {
+		for i := iter.head; i < iter.tail; i++ {
+			c := iter.buf[i]
+			switch c {
+			case ' ', '\n', '\t', '\r':
+				continue
+			}
+			iter.head = i + 1
+			return c
+		}
+		if !iter.loadMore() {
+			return 0
+		}
+	}
+}
+
+// skipComments skips the // and /* */ comments starting from c, returns the token after them
+func (iter *Iterator) skipComments(c byte) byte {
+	for c == '/' {
+		switch next := iter.readByte(); next {
+		case '/':
+			for {
+				c = iter.readByte()
+				if c == '\n' || iter.Error != nil {
+					break
+				}
+			}
+		case '*':
+			for {
+				c = iter.readByte()
+				if iter.Error != nil {
+					iter.ReportError("skipComments", "incomplete comment")
+					return 0
+				}
+				if c == '*' && iter.nextByteIs('/') {
+					break
+				}
+			}
+		default:
+			iter.ReportError("skipComments", "expect // or /*, but found "+string([]byte{next}))
+			return 0
+		}
+		c = iter.nextNonWhitespace()
+	}
+	return c
+}
+
+// nextByteIs consumes the next byte if it is b
+func (iter *Iterator) nextByteIs(b byte) bool {
+	c := iter.readByte()
+	if c == b {
+		return true
+	}
+	iter.unreadByte()
+	return false
+}
+
+// readObjectKey is ReadString for the object key, the key may be unquoted in relaxed mode
+func (iter *Iterator) readObjectKey() string {
+	if iter.nextIsUnquotedKey() {
+		return iter.readUnquotedKey()
+	}
+	return iter.ReadString()
+}
+
+// nextIsUnquotedKey tells if the next token starts an unquoted key in relaxed mode
+func (iter *Iterator) nextIsUnquotedKey() bool {
+	if !iter.cfg.relaxed {
+		return false
+	}
+	c := iter.nextToken()
+	iter.unreadByte()
+	return isIdentifierByte(c) && !(c >= '0' && c <= '9')
+}
+
+func (iter *Iterator) readUnquotedKey() string {
+	str := []byte{iter.nextToken()}
+	for {
+		c := iter.readByte()
+		if iter.Error != nil {
+			break
+		}
+		if !isIdentifierByte(c) {
+			iter.unreadByte()
+			break
+		}
+		str = append(str, c)
+		if !iter.checkStringLength(len(str)) {
+			return ""
+		}
+	}
+	return string(str)
+}
+
+func isIdentifierByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '_' || c == '$' || c >= 0x80
+}
+
+func (iter *Iterator) readSingleQuotedString() (ret string) {
+	var str []byte
+	for {
+		c := iter.readByte()
+		if iter.Error != nil {
+			break
+		}
+		if c == '\'' {
+			return string(str)
+		}
+		if c == '\\' {
+			c = iter.readByte()
+			if c == '\'' {
+				str = append(str, '\'')
+			} else {
+				str = iter.readEscapedChar(c, str)
+			}
+		} else if c < ' ' {
+			iter.ReportError("readSingleQuotedString",
+				fmt.Sprintf(`invalid control character found: %d`, c))
+			return
+		} else {
+			str = append(str, c)
+		}
+		if !iter.checkStringLength(len(str)) {
+			return
+		}
+	}
+	iter.ReportError("readSingleQuotedString", "unexpected end of input")
+	return
+}
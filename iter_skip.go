@@ -89,6 +89,10 @@ func (iter *Iterator) Skip() {
 	case '{':
 		iter.skipObject()
 	default:
+		if c == '\'' && iter.cfg.relaxed {
+			iter.readSingleQuotedString()
+			return
+		}
 		iter.ReportError("Skip", fmt.Sprintf("do not know how to skip: %v", c))
 		return
 	}
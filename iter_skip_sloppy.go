@@ -31,6 +31,10 @@ func (iter *Iterator) skipArray() {
 				i = iter.head - 1 // it will be i++ soon
 			case '[': // If open symbol, increase level
 				level++
+				if iter.depth+level > iter.cfg.maxDepth {
+					iter.reportLimitError("skipObject", "MaxDepth", iter.cfg.maxDepth)
+					return
+				}
 			case ']': // If close symbol, increase level
 				level--
 
@@ -59,6 +63,10 @@ func (iter *Iterator) skipObject() {
 				i = iter.head - 1 // it will be i++ soon
 			case '{': // If open symbol, increase level
 				level++
+				if iter.depth+level > iter.cfg.maxDepth {
+					iter.reportLimitError("skipObject", "MaxDepth", iter.cfg.maxDepth)
+					return
+				}
 			case '}': // If close symbol, increase level
 				level--
 
@@ -5,6 +5,8 @@ package jsoniter
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
+	"unicode/utf8"
 )
 
 func (iter *Iterator) skipNumber() {
@@ -60,15 +62,26 @@ func (iter *Iterator) trySkipNumber() bool {
 
 func (iter *Iterator) skipString() {
 	if !iter.trySkipString() {
+		// not holding the whole string in memory
 		iter.unreadByte()
-		iter.ReadString()
+		iter.ReadStringTo(ioutil.Discard)
 	}
 }
 
 func (iter *Iterator) trySkipString() bool {
+	var bits byte // or of all bytes, to find non-ASCII
 	for i := iter.head; i < iter.tail; i++ {
 		c := iter.buf[i]
+		bits |= c
 		if c == '"' {
+			if !iter.checkStringLength(i - iter.head) {
+				return true // already failed
+			}
+			if bits >= utf8.RuneSelf && iter.cfg.invalidUTF8 == InvalidUTF8Reject &&
+				!utf8.Valid(iter.buf[iter.head:i]) {
+				iter.ReportError("trySkipString", "invalid UTF-8 in string")
+				return true // already failed
+			}
 			iter.head = i + 1
 			return true // valid
 		} else if c == '\\' {
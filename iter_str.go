@@ -3,16 +3,30 @@ package jsoniter
 import (
 	"fmt"
 	"unicode/utf16"
+	"unicode/utf8"
+	"unsafe"
 )
 
 // ReadString read string from iterator
 func (iter *Iterator) ReadString() (ret string) {
 	c := iter.nextToken()
 	if c == '"' {
+		var bits byte // or of all bytes, to find non-ASCII
 		for i := iter.head; i < iter.tail; i++ {
 			c := iter.buf[i]
+			bits |= c
 			if c == '"' {
-				ret = string(iter.buf[iter.head:i])
+				if !iter.checkStringLength(i - iter.head) {
+					return
+				}
+				str := iter.buf[iter.head:i]
+				if bits >= utf8.RuneSelf && iter.cfg.validatesUTF8() {
+					var valid bool
+					if str, valid = iter.checkUTF8(str); !valid {
+						return
+					}
+				}
+				ret = iter.stringOf(str)
 				iter.head = i + 1
 				return ret
 			} else if c == '\\' {
@@ -24,6 +38,8 @@ func (iter *Iterator) ReadString() (ret string) {
 			}
 		}
 		return iter.readStringSlowPath()
+	} else if c == '\'' && iter.cfg.relaxed {
+		return iter.readSingleQuotedString()
 	} else if c == 'n' {
 		iter.skipThreeBytes('u', 'l', 'l')
 		return ""
@@ -32,12 +48,33 @@ func (iter *Iterator) ReadString() (ret string) {
 	return
 }
 
+// stringOf converts bytes of the input to string, the string is interned in InternStrings mode,
+// or aliases the input in ZeroCopyStrings mode unless the input is read from io.Reader
+func (iter *Iterator) stringOf(bytes []byte) string {
+	if iter.cfg.stringInterner != nil {
+		return iter.cfg.stringInterner.intern(bytes)
+	}
+	if iter.cfg.zeroCopyStrings && iter.reader == nil {
+		return *(*string)(unsafe.Pointer(&bytes))
+	}
+	return string(bytes)
+}
+
 func (iter *Iterator) readStringSlowPath() (ret string) {
 	var str []byte
 	var c byte
 	for iter.Error == nil {
 		c = iter.readByte()
 		if c == '"' {
+			if iter.cfg.validatesUTF8() {
+				var valid bool
+				if str, valid = iter.checkUTF8(str); !valid {
+					return
+				}
+			}
+			if iter.cfg.stringInterner != nil {
+				return iter.cfg.stringInterner.intern(str)
+			}
 			return string(str)
 		}
 		if c == '\\' {
@@ -46,6 +83,9 @@ func (iter *Iterator) readStringSlowPath() (ret string) {
 		} else {
 			str = append(str, c)
 		}
+		if !iter.checkStringLength(len(str)) {
+			return
+		}
 	}
 	iter.ReportError("readStringSlowPath", "unexpected end of input")
 	return
@@ -62,15 +102,14 @@ func (iter *Iterator) readEscapedChar(c byte, str []byte) []byte {
 			}
 			if c != '\\' {
 				iter.unreadByte()
-				str = appendRune(str, r)
-				return str
+				return iter.appendEscapedRune(str, r)
 			}
 			c = iter.readByte()
 			if iter.Error != nil {
 				return nil
 			}
 			if c != 'u' {
-				str = appendRune(str, r)
+				str = iter.appendEscapedRune(str, r)
 				return iter.readEscapedChar(c, str)
 			}
 			r2 := iter.readU4()
@@ -79,8 +118,8 @@ func (iter *Iterator) readEscapedChar(c byte, str []byte) []byte {
 			}
 			combined := utf16.DecodeRune(r, r2)
 			if combined == '\uFFFD' {
-				str = appendRune(str, r)
-				str = appendRune(str, r2)
+				str = iter.appendEscapedRune(str, r)
+				str = iter.appendEscapedRune(str, r2)
 			} else {
 				str = appendRune(str, combined)
 			}
@@ -120,6 +159,9 @@ func (iter *Iterator) ReadStringAsSlice() (ret []byte) {
 			// require ascii string and no escape
 			// for: field name, base64, number
 			if iter.buf[i] == '"' {
+				if !iter.checkStringLength(i - iter.head) {
+					return
+				}
 				// fast path: reuse the underlying buffer
 				ret = iter.buf[iter.head:i]
 				iter.head = i + 1
@@ -136,9 +178,15 @@ func (iter *Iterator) ReadStringAsSlice() (ret []byte) {
 				return copied
 			}
 			copied = append(copied, c)
+			if !iter.checkStringLength(len(copied)) {
+				return
+			}
 		}
 		return copied
 	}
+	if c == '\'' && iter.cfg.relaxed {
+		return []byte(iter.readSingleQuotedString())
+	}
 	iter.ReportError("ReadStringAsSlice", `expects " or n, but found `+string([]byte{c}))
 	return
 }
@@ -0,0 +1,210 @@
+package jsoniter
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// ReadStringTo reads string and writes it unescaped into writer, without holding the whole string in memory.
+// null writes nothing. It returns the bytes written, the error of writer is reported as the error of iterator.
+func (iter *Iterator) ReadStringTo(writer io.Writer) int64 {
+	c := iter.nextToken()
+	if c == 'n' {
+		iter.skipThreeBytes('u', 'l', 'l')
+		return 0
+	}
+	if c != '"' {
+		iter.ReportError("ReadStringTo", `expects " or n, but found `+string([]byte{c}))
+		return 0
+	}
+	out := &stringWriter{iter: iter, writer: writer}
+	for {
+		i := iter.head
+		for ; i < iter.tail; i++ {
+			if c := iter.buf[i]; c == '"' || c == '\\' || c < ' ' {
+				break
+			}
+		}
+		if !out.write(iter.buf[iter.head:i]) {
+			return out.written
+		}
+		iter.head = i
+		if i == iter.tail {
+			if !iter.loadMore() {
+				iter.ReportError("ReadStringTo", "unexpected end of input")
+				return out.written
+			}
+			continue
+		}
+		c := iter.buf[i]
+		iter.head = i + 1
+		switch {
+		case c == '"':
+			out.finish()
+			return out.written
+		case c == '\\':
+			var escaped [utf8.UTFMax * 2]byte
+			char := iter.readEscapedChar(iter.readByte(), escaped[:0])
+			if iter.Error != nil && iter.Error != io.EOF || !out.writeEscaped(char) {
+				return out.written
+			}
+		default:
+			iter.ReportError("ReadStringTo", fmt.Sprintf(`invalid control character found: %d`, c))
+			return out.written
+		}
+	}
+}
+
+// ReadBase64To reads string of base64, and writes the decoded bytes into writer
+// without holding the whole string in memory. nil encoding is base64.StdEncoding, the same as []byte.
+// The line breaks in the string are ignored. It returns the bytes written.
+func (iter *Iterator) ReadBase64To(writer io.Writer, encoding *base64.Encoding) int64 {
+	if encoding == nil {
+		encoding = base64.StdEncoding
+	}
+	out := &base64Writer{encoding: encoding, writer: writer}
+	iter.ReadStringTo(out)
+	if iter.Error != nil && iter.Error != io.EOF {
+		return out.written
+	}
+	if err := out.close(); err != nil {
+		iter.reportUnderlyingError("ReadBase64To", err)
+	}
+	return out.written
+}
+
+// stringWriter writes the string to writer, applying the policy of invalid UTF-8
+type stringWriter struct {
+	iter       *Iterator
+	writer     io.Writer
+	written    int64
+	pending    [utf8.UTFMax]byte // the incomplete rune at the end of the previous write
+	pendingLen int
+	scratch    []byte
+}
+
+// write writes the bytes of input
+func (out *stringWriter) write(run []byte) bool {
+	if !out.iter.cfg.validatesUTF8() {
+		return out.writeOut(run)
+	}
+	data := run
+	if out.pendingLen > 0 {
+		out.scratch = append(append(out.scratch[:0], out.pending[:out.pendingLen]...), run...)
+		data = out.scratch
+	}
+	// the rune cut by the end of buffer is completed by the next write
+	cut := len(data)
+	for j := len(data) - 1; j >= 0 && j > len(data)-utf8.UTFMax; j-- {
+		if utf8.RuneStart(data[j]) {
+			if !utf8.FullRune(data[j:]) {
+				cut = j
+			}
+			break
+		}
+	}
+	out.pendingLen = copy(out.pending[:], data[cut:])
+	return out.writeValidated(data[:cut])
+}
+
+// writeEscaped writes the character of escape, which is valid
+func (out *stringWriter) writeEscaped(char []byte) bool {
+	return out.flushPending() && out.writeOut(char)
+}
+
+// finish writes the incomplete rune at the end of string
+func (out *stringWriter) finish() bool {
+	return out.flushPending()
+}
+
+func (out *stringWriter) flushPending() bool {
+	if out.pendingLen == 0 {
+		return true
+	}
+	pending := out.pending[:out.pendingLen]
+	out.pendingLen = 0
+	return out.writeValidated(pending)
+}
+
+func (out *stringWriter) writeValidated(data []byte) bool {
+	if utf8.Valid(data) {
+		return out.writeOut(data)
+	}
+	if out.iter.cfg.invalidUTF8 == InvalidUTF8Reject {
+		out.iter.ReportError("ReadStringTo", "invalid UTF-8 in string")
+		return false
+	}
+	out.scratch = appendValidUTF8(out.scratch[:0], data)
+	return out.writeOut(out.scratch)
+}
+
+func (out *stringWriter) writeOut(data []byte) bool {
+	if len(data) == 0 {
+		return true
+	}
+	n, err := out.writer.Write(data)
+	out.written += int64(n)
+	if err != nil {
+		out.iter.reportUnderlyingError("ReadStringTo", err)
+		return false
+	}
+	return out.iter.checkStringLength(int(out.written))
+}
+
+// base64ChunkSize is the base64 bytes decoded at a time, a multiple of 4
+const base64ChunkSize = 4096
+
+// base64Writer decodes base64 written in pieces, collecting base64ChunkSize bytes to decode and write at a time
+type base64Writer struct {
+	encoding   *base64.Encoding
+	writer     io.Writer
+	written    int64
+	read       int64 // base64 bytes decoded
+	encoded    [base64ChunkSize]byte
+	encodedLen int
+	padded     bool // the padding is found, no more data is allowed
+	decoded    [base64ChunkSize / 4 * 3]byte
+}
+
+func (out *base64Writer) Write(p []byte) (int, error) {
+	for i, c := range p {
+		if c == '\r' || c == '\n' {
+			continue
+		}
+		if c == '=' {
+			out.padded = true
+		} else if out.padded {
+			return i, base64.CorruptInputError(out.read + int64(out.encodedLen))
+		}
+		out.encoded[out.encodedLen] = c
+		out.encodedLen++
+		if out.encodedLen == len(out.encoded) {
+			if err := out.flush(); err != nil {
+				return i, err
+			}
+		}
+	}
+	return len(p), nil
+}
+
+func (out *base64Writer) flush() error {
+	n, err := out.encoding.Decode(out.decoded[:], out.encoded[:out.encodedLen])
+	if corrupt, isCorrupt := err.(base64.CorruptInputError); isCorrupt {
+		return base64.CorruptInputError(out.read + int64(corrupt))
+	}
+	out.read += int64(out.encodedLen)
+	out.encodedLen = 0
+	n, err = out.writer.Write(out.decoded[:n])
+	out.written += int64(n)
+	return err
+}
+
+// close decodes the rest, the last group may be without padding
+func (out *base64Writer) close() error {
+	if out.encodedLen == 0 {
+		return nil
+	}
+	return out.flush()
+}
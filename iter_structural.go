@@ -0,0 +1,340 @@
+package jsoniter
+
+import (
+	"math"
+	"math/bits"
+	"sync/atomic"
+	"unsafe"
+)
+
+// structuralIndex records the offsets of every structural character ({ } [ ] : ,) outside of strings,
+// so that the lazy Any can jump to the children of arrays and objects, instead of skipping the values before them.
+// It is built in one pass over blocks of 64 bytes, finding the strings by bitmask operations
+// in the same way as the stage 1 of simdjson.
+type structuralIndex struct {
+	buf        []byte
+	positions  []uint32
+	matches    []uint32 // for { and [, the index of the matching } or ]
+	elements   []uint32 // for [, the index in separators of its separators
+	separators []uint32 // the number of separators followed by the indexes of the [ and the commas, for every array
+}
+
+const (
+	structuralOther = iota
+	structuralQuote
+	structuralBackslash
+	structuralChar
+)
+
+var structuralClasses [256]byte
+
+func init() {
+	structuralClasses['"'] = structuralQuote
+	structuralClasses['\\'] = structuralBackslash
+	for _, c := range []byte("{}[]:,") {
+		structuralClasses[c] = structuralChar
+	}
+}
+
+// oddBits has the bits of odd positions set
+const oddBits = 0xAAAAAAAAAAAAAAAA
+
+// newStructuralIndex indexes buf, it returns nil if the brackets or quotes are not balanced
+func newStructuralIndex(buf []byte) *structuralIndex {
+	if uint64(len(buf)) > math.MaxUint32 {
+		return nil
+	}
+	index := &structuralIndex{buf: buf}
+	var stack []uint32
+	var marks []int         // for the brackets in stack, the length of pending when opened
+	var pending []uint32    // the [ and commas of the arrays not closed yet
+	var prevEscaped uint64  // 1 if the first byte of the block is escaped
+	var prevInString uint64 // all ones if the previous block ended inside a string
+	for blockStart := 0; blockStart < len(buf); blockStart += 64 {
+		block := buf[blockStart:]
+		if len(block) > 64 {
+			block = block[:64]
+		}
+		var quotes, backslashes, structurals uint64
+		for i, c := range block {
+			switch structuralClasses[c] {
+			case structuralQuote:
+				quotes |= 1 << uint(i)
+			case structuralBackslash:
+				backslashes |= 1 << uint(i)
+			case structuralChar:
+				structurals |= 1 << uint(i)
+			}
+		}
+		quotes &^= findEscaped(backslashes, &prevEscaped)
+		// the bits from the opening quote to the byte before the closing quote
+		inString := prefixXor(quotes) ^ prevInString
+		prevInString = uint64(int64(inString) >> 63)
+		structurals &^= inString
+		for structurals != 0 {
+			pos := uint32(blockStart + bits.TrailingZeros64(structurals))
+			structurals &= structurals - 1
+			idx := uint32(len(index.positions))
+			index.positions = append(index.positions, pos)
+			index.matches = append(index.matches, 0)
+			index.elements = append(index.elements, 0)
+			switch c := buf[pos]; c {
+			case '{', '[':
+				stack = append(stack, idx)
+				marks = append(marks, len(pending))
+				if c == '[' {
+					pending = append(pending, idx)
+				}
+			case ',':
+				pending = append(pending, idx)
+			case '}', ']':
+				if len(stack) == 0 {
+					return nil
+				}
+				open := stack[len(stack)-1]
+				if buf[index.positions[open]] != c-2 { // '{' is '}'-2 and '[' is ']'-2
+					return nil
+				}
+				mark := marks[len(marks)-1]
+				stack, marks = stack[:len(stack)-1], marks[:len(marks)-1]
+				index.matches[open] = idx
+				if c == ']' {
+					index.elements[open] = uint32(len(index.separators))
+					index.separators = append(index.separators, uint32(len(pending)-mark))
+					index.separators = append(index.separators, pending[mark:]...)
+				}
+				pending = pending[:mark]
+			}
+		}
+	}
+	if prevInString != 0 || len(stack) != 0 {
+		return nil
+	}
+	return index
+}
+
+// findEscaped returns the bits of the bytes escaped by backslashes.
+// prevEscaped carries if the first byte of the next block is escaped.
+func findEscaped(backslashes uint64, prevEscaped *uint64) uint64 {
+	if backslashes == 0 {
+		escaped := *prevEscaped
+		*prevEscaped = 0
+		return escaped
+	}
+	potentialEscape := backslashes &^ *prevEscaped
+	// subtracting the start of backslash sequences from odd bits,
+	// the carry flips the bits after the sequences of odd length
+	maybeEscaped := potentialEscape << 1
+	escapeAndTerminalCode := ((maybeEscaped | oddBits) - potentialEscape) ^ oddBits
+	escaped := escapeAndTerminalCode ^ (backslashes | *prevEscaped)
+	escape := escapeAndTerminalCode & backslashes
+	*prevEscaped = escape >> 63
+	return escaped
+}
+
+// prefixXor sets every bit to the xor of it and all the bits before it
+func prefixXor(bitmask uint64) uint64 {
+	bitmask ^= bitmask << 1
+	bitmask ^= bitmask << 2
+	bitmask ^= bitmask << 4
+	bitmask ^= bitmask << 8
+	bitmask ^= bitmask << 16
+	bitmask ^= bitmask << 32
+	return bitmask
+}
+
+func (index *structuralIndex) char(i int) byte {
+	return index.buf[index.positions[i]]
+}
+
+// between returns the bytes between the structural characters i and i+1, without whitespaces
+func (index *structuralIndex) between(i int) []byte {
+	start := int(index.positions[i]) + 1
+	end := int(index.positions[i+1])
+	for start < end && isWhitespace(index.buf[start]) {
+		start++
+	}
+	for end > start && isWhitespace(index.buf[end-1]) {
+		end--
+	}
+	return index.buf[start:end]
+}
+
+func isWhitespace(c byte) bool {
+	switch c {
+	case ' ', '\n', '\t', '\r':
+		return true
+	}
+	return false
+}
+
+// valueAfter locates the value after the structural character i.
+// It returns the bytes of the value, the index of its open bracket (-1 if not array or object),
+// and the index of the structural character following the value.
+func (index *structuralIndex) valueAfter(i int) (value []byte, open int, next int) {
+	value = index.between(i)
+	if len(value) == 0 {
+		if c := index.char(i + 1); c == '{' || c == '[' {
+			open = i + 1
+			close := int(index.matches[open])
+			return index.buf[index.positions[open] : index.positions[close]+1], open, close + 1
+		}
+	}
+	return value, -1, i + 1
+}
+
+// eachElement calls callback with every element of the array opened at open,
+// it returns false if the array is malformed or exceeds MaxArrayElements, the iterator reports the error then
+func (index *structuralIndex) eachElement(cfg *frozenConfig, open int, callback func(value []byte, valueOpen int) bool) bool {
+	i := open
+	if index.char(i+1) == ']' && len(index.between(i)) == 0 {
+		return true
+	}
+	for length := 1; ; length++ {
+		if length > cfg.maxArrayElements {
+			return false
+		}
+		value, valueOpen, next := index.valueAfter(i)
+		if len(value) == 0 {
+			return false
+		}
+		if !callback(value, valueOpen) {
+			return true
+		}
+		switch index.char(next) {
+		case ',':
+			i = next
+		case ']':
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// element locates the element i of the array opened at open by its separator, without walking the elements before it.
+// The value is nil if i is out of range.
+// It returns false if the element is malformed or the array exceeds MaxArrayElements, the iterator reports the error then.
+func (index *structuralIndex) element(cfg *frozenConfig, open int, i int) (value []byte, valueOpen int, ok bool) {
+	separators := index.separators[index.elements[open]:]
+	length := int(separators[0])
+	separators = separators[1 : length+1]
+	if length == 1 && index.char(open+1) == ']' && len(index.between(open)) == 0 {
+		length = 0
+	}
+	if length > cfg.maxArrayElements {
+		return nil, -1, false
+	}
+	if i < 0 || i >= length {
+		return nil, -1, true
+	}
+	value, valueOpen, next := index.valueAfter(int(separators[i]))
+	if len(value) == 0 {
+		return nil, -1, false
+	}
+	if c := index.char(next); c != ',' && c != ']' {
+		return nil, -1, false
+	}
+	return value, valueOpen, true
+}
+
+// eachField calls callback with every field of the object opened at open, the key is still quoted.
+// It returns false if the object is malformed or exceeds MaxObjectMembers, the iterator reports the error then.
+func (index *structuralIndex) eachField(cfg *frozenConfig, open int, callback func(key []byte, value []byte, valueOpen int) bool) bool {
+	i := open
+	if index.char(i+1) == '}' && len(index.between(i)) == 0 {
+		return true
+	}
+	for members := 1; ; members++ {
+		if members > cfg.maxObjectMembers {
+			return false
+		}
+		key := index.between(i)
+		if index.char(i+1) != ':' || len(key) < 2 || key[0] != '"' || key[len(key)-1] != '"' {
+			return false
+		}
+		value, valueOpen, next := index.valueAfter(i + 1)
+		if len(value) == 0 {
+			return false
+		}
+		if !callback(key, value, valueOpen) {
+			return true
+		}
+		switch index.char(next) {
+		case ',':
+			i = next
+		case '}':
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// keyEquals compares the quoted key with the field name
+func keyEquals(cfg *frozenConfig, key []byte, field string) bool {
+	unquoted := key[1 : len(key)-1]
+	for _, c := range unquoted {
+		if c == '\\' {
+			return unquoteKey(cfg, key) == field
+		}
+	}
+	return string(unquoted) == field
+}
+
+func unquoteKey(cfg *frozenConfig, key []byte) string {
+	iter := cfg.BorrowIterator(key)
+	defer cfg.ReturnIterator(iter)
+	return iter.ReadString()
+}
+
+// valueAny creates Any of the value found in the index
+func (index *structuralIndex) valueAny(cfg *frozenConfig, value []byte, open int) Any {
+	if open >= 0 {
+		lazy := lazyIndex{unsafe.Pointer(index), open}
+		if value[0] == '{' {
+			return &objectLazyAny{baseAny{}, cfg, value, nil, lazy}
+		}
+		return &arrayLazyAny{baseAny{}, cfg, value, nil, lazy}
+	}
+	iter := cfg.BorrowIterator(value)
+	defer cfg.ReturnIterator(iter)
+	return iter.readAny()
+}
+
+// getPath gets path from the value found in the index, the same way as locatePath.
+// Unlike Get, the value of number, string, bool or null is returned as is for empty path.
+func getPath(found Any, path []interface{}) Any {
+	if len(path) == 0 {
+		return found
+	}
+	return found.Get(path...)
+}
+
+// lazyIndex is the structural index used by the lazy Any of array or object,
+// built on first use if the Any is not created from the index of its parent
+type lazyIndex struct {
+	ptr unsafe.Pointer // *structuralIndex
+	at  int            // the index of the open bracket of the Any
+}
+
+var invalidStructuralIndex = &structuralIndex{}
+
+func (lazy *lazyIndex) get(cfg *frozenConfig, buf []byte) (*structuralIndex, int) {
+	if cfg.relaxed {
+		// the comments may contain brackets and quotes
+		return nil, 0
+	}
+	index := (*structuralIndex)(atomic.LoadPointer(&lazy.ptr))
+	if index == nil {
+		index = newStructuralIndex(buf)
+		if index == nil {
+			index = invalidStructuralIndex
+		}
+		atomic.StorePointer(&lazy.ptr, unsafe.Pointer(index))
+	}
+	if index == invalidStructuralIndex {
+		return nil, 0
+	}
+	return index, lazy.at
+}
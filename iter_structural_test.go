@@ -0,0 +1,106 @@
+package jsoniter
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// naiveStructurals finds the structural characters byte by byte
+func naiveStructurals(buf []byte) []uint32 {
+	var positions []uint32
+	inString, escaped := false, false
+	for i, c := range buf {
+		switch {
+		case !inString && structuralClasses[c] == structuralChar:
+			positions = append(positions, uint32(i))
+		case c == '"' && !escaped:
+			inString = !inString
+		}
+		escaped = c == '\\' && !escaped
+	}
+	return positions
+}
+
+func Test_structural_index_matches_naive_scan(t *testing.T) {
+	should := require.New(t)
+	alphabet := []byte(`:,"\\\\ a1`)
+	random := rand.New(rand.NewSource(1))
+	for round := 0; round < 2000; round++ {
+		buf := make([]byte, random.Intn(300)+2)
+		for i := range buf {
+			buf[i] = alphabet[random.Intn(len(alphabet))]
+		}
+		buf[0], buf[len(buf)-1] = '[', ']'
+		index := newStructuralIndex(buf)
+		if index == nil {
+			continue
+		}
+		should.Equal(naiveStructurals(buf), index.positions, string(buf))
+	}
+}
+
+func Test_structural_index_escapes_across_blocks(t *testing.T) {
+	should := require.New(t)
+	for prefix := 55; prefix < 70; prefix++ {
+		for backslashes := 1; backslashes < 5; backslashes++ {
+			buf := []byte(`[["`)
+			for i := 0; i < prefix; i++ {
+				buf = append(buf, 'a')
+			}
+			for i := 0; i < backslashes; i++ {
+				buf = append(buf, '\\')
+			}
+			buf = append(buf, `"],[1]]`...)
+			index := newStructuralIndex(buf)
+			if backslashes%2 == 1 {
+				should.Nil(index) // the closing quote is escaped
+				continue
+			}
+			should.NotNil(index)
+			should.Equal(naiveStructurals(buf), index.positions)
+			should.Equal(uint32(len(index.positions)-1), index.matches[0])
+		}
+	}
+}
+
+func Test_structural_index_unbalanced(t *testing.T) {
+	should := require.New(t)
+	should.Nil(newStructuralIndex([]byte(`[1,2`)))
+	should.Nil(newStructuralIndex([]byte(`[1,2}`)))
+	should.Nil(newStructuralIndex([]byte(`{"a]`)))
+	should.NotNil(newStructuralIndex([]byte(`{"a]":[]}`)))
+}
+
+func Test_structural_index_element(t *testing.T) {
+	should := require.New(t)
+	for _, input := range []string{`[]`, `[ ]`, `[1]`, `[1, "a,]", {"b": [2, 3]}, [[], [4]], null]`, `{"a": [5, {"c": 6, "d": 7}]}`} {
+		buf := []byte(input)
+		index := newStructuralIndex(buf)
+		should.NotNil(index, input)
+		for open := range index.positions {
+			if index.char(open) != '[' {
+				continue
+			}
+			var expected []string
+			should.True(index.eachElement(ConfigDefault.(*frozenConfig), open, func(value []byte, valueOpen int) bool {
+				expected = append(expected, string(value))
+				return true
+			}))
+			for i := -1; i <= len(expected); i++ {
+				value, _, ok := index.element(ConfigDefault.(*frozenConfig), open, i)
+				should.True(ok, input)
+				if i < 0 || i == len(expected) {
+					should.Nil(value, input)
+					continue
+				}
+				should.Equal(expected[i], string(value), input)
+			}
+		}
+	}
+	_, _, ok := newStructuralIndex([]byte(`[1,,2]`)).element(ConfigDefault.(*frozenConfig), 0, 1)
+	should.False(ok)
+	_, _, ok = newStructuralIndex([]byte(`[1,2]`)).element(Config{MaxArrayElements: 1}.Froze().(*frozenConfig), 0, 0)
+	should.False(ok)
+}
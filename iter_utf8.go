@@ -0,0 +1,73 @@
+package jsoniter
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// InvalidUTF8Policy tells how the invalid UTF-8 bytes and the unpaired surrogate escapes (\ud800) in strings are handled
+type InvalidUTF8Policy int
+
+const (
+	// InvalidUTF8Default is the behavior without policy, the invalid bytes are passed through,
+	// the unpaired surrogates are decoded as U+FFFD, and encoded as � only if EscapeHTML
+	InvalidUTF8Default InvalidUTF8Policy = iota
+	// InvalidUTF8Reject reports them as error by decoding, encoding and Valid
+	InvalidUTF8Reject
+	// InvalidUTF8Replace replaces them by U+FFFD as encoding/json does
+	InvalidUTF8Replace
+	// InvalidUTF8PassThrough keeps the invalid bytes, the unpaired surrogates are decoded as WTF-8
+	InvalidUTF8PassThrough
+)
+
+// validatesUTF8 tells if the strings should be checked by the policy
+func (cfg *frozenConfig) validatesUTF8() bool {
+	return cfg.invalidUTF8 == InvalidUTF8Reject || cfg.invalidUTF8 == InvalidUTF8Replace
+}
+
+// checkUTF8 applies the policy to the string with bytes of 0x80 or above,
+// it returns the valid string, or false if the string is rejected
+func (iter *Iterator) checkUTF8(str []byte) ([]byte, bool) {
+	if utf8.Valid(str) {
+		return str, true
+	}
+	if iter.cfg.invalidUTF8 == InvalidUTF8Reject {
+		iter.ReportError("ReadString", "invalid UTF-8 in string")
+		return nil, false
+	}
+	return appendValidUTF8(nil, str), true
+}
+
+// appendValidUTF8 appends str with every invalid byte replaced by U+FFFD
+func appendValidUTF8(dst []byte, str []byte) []byte {
+	for i := 0; i < len(str); {
+		if str[i] < utf8.RuneSelf {
+			dst = append(dst, str[i])
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRune(str[i:])
+		if r == utf8.RuneError && size == 1 {
+			dst = appendRune(dst, utf8.RuneError)
+		} else {
+			dst = append(dst, str[i:i+size]...)
+		}
+		i += size
+	}
+	return dst
+}
+
+// appendEscapedRune appends the rune of \u escape, the unpaired surrogate is handled by the policy
+func (iter *Iterator) appendEscapedRune(str []byte, r rune) []byte {
+	if r < surrogateMin || r > surrogateMax {
+		return appendRune(str, r)
+	}
+	switch iter.cfg.invalidUTF8 {
+	case InvalidUTF8Reject:
+		iter.ReportError("readEscapedChar", fmt.Sprintf(`unpaired surrogate \u%04x`, r))
+		return str
+	case InvalidUTF8PassThrough:
+		return append(str, t3|byte(r>>12), tx|byte(r>>6)&maskx, tx|byte(r)&maskx)
+	}
+	return appendRune(str, utf8.RuneError)
+}
@@ -0,0 +1,991 @@
+package jsoniter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// JSONPath is a compiled RFC 9535 JSONPath query, such as $.store.book[?@.price < 10].title.
+// It supports the name, wildcard, index, slice and filter selectors, the descendant segment (..),
+// and the functions length, count, match, search and value.
+// It is safe for concurrent use.
+type JSONPath struct {
+	source   string
+	segments []jsonPathSegment
+}
+
+// CompileJSONPath parses the JSONPath query
+func CompileJSONPath(path string) (*JSONPath, error) {
+	parser := &jsonPathParser{src: path}
+	if !parser.consume('$') {
+		return nil, parser.error("expect $ at the beginning")
+	}
+	segments, err := parser.parseSegments()
+	if err != nil {
+		return nil, err
+	}
+	if parser.pos != len(path) {
+		return nil, parser.error("unexpected " + strconv.Quote(path[parser.pos:]))
+	}
+	return &JSONPath{path, segments}, nil
+}
+
+// MustCompileJSONPath is CompileJSONPath panicking on error
+func MustCompileJSONPath(path string) *JSONPath {
+	compiled, err := CompileJSONPath(path)
+	if err != nil {
+		panic(err)
+	}
+	return compiled
+}
+
+// String returns the source of the query
+func (path *JSONPath) String() string {
+	return path.source
+}
+
+// Query selects the nodes from root, it returns Any of array holding the nodes in order.
+// The values not visited by the query are not parsed.
+func (path *JSONPath) Query(root Any) Any {
+	if root.ValueType() == InvalidValue {
+		return root
+	}
+	return wrapArray(applySegments(path.segments, []Any{root}, root))
+}
+
+func queryAny(any Any, path string) Any {
+	compiled, err := CompileJSONPath(path)
+	if err != nil {
+		return &invalidAny{baseAny{}, err}
+	}
+	return compiled.Query(any)
+}
+
+type jsonPathSegment struct {
+	descendant bool
+	selectors  []jsonPathSelector
+}
+
+type jsonPathSelectorKind int
+
+const (
+	nameSelector jsonPathSelectorKind = iota
+	wildcardSelector
+	indexSelector
+	sliceSelector
+	filterSelector
+)
+
+type jsonPathSelector struct {
+	kind     jsonPathSelectorKind
+	name     string
+	index    int64 // also the start of slice
+	end      int64
+	step     int64
+	hasStart bool
+	hasEnd   bool
+	filter   jsonPathExpr
+}
+
+func applySegments(segments []jsonPathSegment, nodes []Any, root Any) []Any {
+	for _, segment := range segments {
+		var selected []Any
+		for _, node := range nodes {
+			if segment.descendant {
+				for _, descendant := range jsonPathDescendants(node, nil) {
+					selected = segment.selectFrom(descendant, root, selected)
+				}
+			} else {
+				selected = segment.selectFrom(node, root, selected)
+			}
+		}
+		nodes = selected
+	}
+	if nodes == nil {
+		return []Any{}
+	}
+	return nodes
+}
+
+func (segment *jsonPathSegment) selectFrom(node Any, root Any, selected []Any) []Any {
+	var children []Any // the children are listed on demand, shared by the selectors
+	listChildren := func() []Any {
+		if children == nil {
+			children = jsonPathChildren(node)
+		}
+		return children
+	}
+	for i := range segment.selectors {
+		selector := &segment.selectors[i]
+		switch selector.kind {
+		case nameSelector:
+			if node.ValueType() == ObjectValue {
+				if found := node.Get(selector.name); found.ValueType() != InvalidValue {
+					selected = append(selected, found)
+				}
+			}
+		case wildcardSelector:
+			selected = append(selected, listChildren()...)
+		case indexSelector:
+			if node.ValueType() == ArrayValue {
+				index := selector.index
+				if index < 0 {
+					index += int64(node.Size())
+				}
+				if index >= 0 && index <= maxJSONPathInt {
+					if found := node.Get(int(index)); found.ValueType() != InvalidValue {
+						selected = append(selected, found)
+					}
+				}
+			}
+		case sliceSelector:
+			if node.ValueType() == ArrayValue {
+				selected = selector.slice(listChildren(), selected)
+			}
+		case filterSelector:
+			for _, child := range listChildren() {
+				if selector.filter.test(child, root) {
+					selected = append(selected, child)
+				}
+			}
+		}
+	}
+	return selected
+}
+
+// slice selects the elements by start:end:step as RFC 9535 section 2.3.4.2.2
+func (selector *jsonPathSelector) slice(elements []Any, selected []Any) []Any {
+	length := int64(len(elements))
+	step := selector.step
+	if step == 0 {
+		return selected
+	}
+	normalize := func(i int64) int64 {
+		if i >= 0 {
+			return i
+		}
+		return length + i
+	}
+	clamp := func(i int64, min int64, max int64) int64 {
+		if i < min {
+			return min
+		}
+		if i > max {
+			return max
+		}
+		return i
+	}
+	start, end := int64(0), length
+	if step < 0 {
+		start, end = length-1, -length-1
+	}
+	if selector.hasStart {
+		start = selector.index
+	}
+	if selector.hasEnd {
+		end = selector.end
+	}
+	if step > 0 {
+		lower, upper := clamp(normalize(start), 0, length), clamp(normalize(end), 0, length)
+		for i := lower; i < upper; i += step {
+			selected = append(selected, elements[i])
+		}
+		return selected
+	}
+	upper, lower := clamp(normalize(start), -1, length-1), clamp(normalize(end), -1, length-1)
+	for i := upper; lower < i; i += step {
+		selected = append(selected, elements[i])
+	}
+	return selected
+}
+
+// jsonPathChildren returns the elements of array or the member values of object, in order
+func jsonPathChildren(node Any) []Any {
+	var children []Any
+	switch node.ValueType() {
+	case ArrayValue:
+		if lazy, isLazy := node.(*arrayLazyAny); isLazy {
+			return lazy.children()
+		}
+		for i := 0; i < node.Size(); i++ {
+			children = append(children, node.Get(i))
+		}
+	case ObjectValue:
+		if lazy, isLazy := node.(*objectLazyAny); isLazy && lazy.cfg.duplicateKeys == DuplicateKeysDefault {
+			return lazy.children()
+		}
+		for _, key := range node.Keys() {
+			children = append(children, node.Get(key))
+		}
+	}
+	return children
+}
+
+// jsonPathDescendants appends the node and its descendants in document order
+func jsonPathDescendants(node Any, descendants []Any) []Any {
+	descendants = append(descendants, node)
+	for _, child := range jsonPathChildren(node) {
+		descendants = jsonPathDescendants(child, descendants)
+	}
+	return descendants
+}
+
+const maxJSONPathInt = 1<<53 - 1
+
+// the expressions of filter
+
+type jsonPathExpr interface {
+	test(current Any, root Any) bool
+}
+
+type orExpr []jsonPathExpr
+
+func (expr orExpr) test(current Any, root Any) bool {
+	for _, operand := range expr {
+		if operand.test(current, root) {
+			return true
+		}
+	}
+	return false
+}
+
+type andExpr []jsonPathExpr
+
+func (expr andExpr) test(current Any, root Any) bool {
+	for _, operand := range expr {
+		if !operand.test(current, root) {
+			return false
+		}
+	}
+	return true
+}
+
+type notExpr struct {
+	operand jsonPathExpr
+}
+
+func (expr notExpr) test(current Any, root Any) bool {
+	return !expr.operand.test(current, root)
+}
+
+// existExpr tests the query selects any node
+type existExpr struct {
+	query *jsonPathFilterQuery
+}
+
+func (expr existExpr) test(current Any, root Any) bool {
+	return len(expr.query.nodes(current, root)) > 0
+}
+
+// functionTestExpr tests the result of match or search
+type functionTestExpr struct {
+	function *jsonPathFunction
+}
+
+func (expr functionTestExpr) test(current Any, root Any) bool {
+	result, _ := expr.function.value(current, root)
+	return result == true
+}
+
+type comparisonExpr struct {
+	op    string
+	left  jsonPathComparable
+	right jsonPathComparable
+}
+
+func (expr comparisonExpr) test(current Any, root Any) bool {
+	left, leftExists := expr.left.value(current, root)
+	right, rightExists := expr.right.value(current, root)
+	switch expr.op {
+	case "==":
+		return jsonPathEqual(left, leftExists, right, rightExists)
+	case "!=":
+		return !jsonPathEqual(left, leftExists, right, rightExists)
+	case "<":
+		return jsonPathLess(left, leftExists, right, rightExists)
+	case "<=":
+		return jsonPathLess(left, leftExists, right, rightExists) || jsonPathEqual(left, leftExists, right, rightExists)
+	case ">":
+		return jsonPathLess(right, rightExists, left, leftExists)
+	default: // >=
+		return jsonPathLess(right, rightExists, left, leftExists) || jsonPathEqual(left, leftExists, right, rightExists)
+	}
+}
+
+// jsonPathEqual compares the values, Nothing (not exists) equals only to Nothing
+func jsonPathEqual(left interface{}, leftExists bool, right interface{}, rightExists bool) bool {
+	if !leftExists || !rightExists {
+		return leftExists == rightExists
+	}
+	return reflect.DeepEqual(left, right)
+}
+
+// jsonPathLess compares numbers or strings
+func jsonPathLess(left interface{}, leftExists bool, right interface{}, rightExists bool) bool {
+	if !leftExists || !rightExists {
+		return false
+	}
+	switch left := left.(type) {
+	case float64:
+		right, isNumber := right.(float64)
+		return isNumber && left < right
+	case string:
+		right, isString := right.(string)
+		return isString && left < right
+	}
+	return false
+}
+
+// jsonPathComparable is literal, singular query or function returning value.
+// The value is float64, string, bool, nil, []interface{} or map[string]interface{},
+// and false if Nothing.
+type jsonPathComparable interface {
+	value(current Any, root Any) (interface{}, bool)
+}
+
+type jsonPathLiteral struct {
+	val interface{}
+}
+
+func (literal jsonPathLiteral) value(current Any, root Any) (interface{}, bool) {
+	return literal.val, true
+}
+
+// jsonPathFilterQuery is the query relative to current node (@) or root ($) in filter
+type jsonPathFilterQuery struct {
+	absolute bool
+	segments []jsonPathSegment
+}
+
+func (query *jsonPathFilterQuery) nodes(current Any, root Any) []Any {
+	start := current
+	if query.absolute {
+		start = root
+	}
+	return applySegments(query.segments, []Any{start}, root)
+}
+
+// value is the value of the only node selected, used for the singular query or value()
+func (query *jsonPathFilterQuery) value(current Any, root Any) (interface{}, bool) {
+	nodes := query.nodes(current, root)
+	if len(nodes) != 1 {
+		return nil, false
+	}
+	return jsonPathValueOf(nodes[0]), true
+}
+
+// singular tells if the query selects at most one node
+func (query *jsonPathFilterQuery) singular() bool {
+	for _, segment := range query.segments {
+		if segment.descendant || len(segment.selectors) != 1 {
+			return false
+		}
+		if kind := segment.selectors[0].kind; kind != nameSelector && kind != indexSelector {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonPathValueOf converts the node into the value to compare
+func jsonPathValueOf(node Any) interface{} {
+	switch node.ValueType() {
+	case NumberValue:
+		return node.ToFloat64()
+	case StringValue:
+		return node.ToString()
+	case BoolValue:
+		return node.ToBool()
+	case NilValue:
+		return nil
+	}
+	stream := ConfigDefault.BorrowStream(nil)
+	defer ConfigDefault.ReturnStream(stream)
+	node.WriteTo(stream)
+	var val interface{}
+	if err := ConfigDefault.Unmarshal(stream.Buffer(), &val); err != nil {
+		return nil
+	}
+	return val
+}
+
+type jsonPathFunction struct {
+	name    string
+	args    []interface{} // jsonPathComparable or *jsonPathFilterQuery
+	pattern *regexp.Regexp
+}
+
+// jsonPathFunctionTypes is the result type and parameter types of the functions,
+// "value" is ValueType, "nodes" is NodesType, "logical" is LogicalType
+var jsonPathFunctionTypes = map[string][]string{
+	"length": {"value", "value"},
+	"count":  {"value", "nodes"},
+	"match":  {"logical", "value", "value"},
+	"search": {"logical", "value", "value"},
+	"value":  {"value", "nodes"},
+}
+
+func (function *jsonPathFunction) value(current Any, root Any) (interface{}, bool) {
+	switch function.name {
+	case "length":
+		arg, exists := function.args[0].(jsonPathComparable).value(current, root)
+		if !exists {
+			return nil, false
+		}
+		switch arg := arg.(type) {
+		case string:
+			return float64(utf8.RuneCountInString(arg)), true
+		case []interface{}:
+			return float64(len(arg)), true
+		case map[string]interface{}:
+			return float64(len(arg)), true
+		}
+		return nil, false
+	case "count":
+		return float64(len(function.args[0].(*jsonPathFilterQuery).nodes(current, root))), true
+	case "value":
+		return function.args[0].(*jsonPathFilterQuery).value(current, root)
+	}
+	// match or search
+	str, exists := function.args[0].(jsonPathComparable).value(current, root)
+	input, isString := str.(string)
+	if !exists || !isString {
+		return false, true
+	}
+	pattern := function.pattern
+	if pattern == nil {
+		arg, exists := function.args[1].(jsonPathComparable).value(current, root)
+		source, isString := arg.(string)
+		if !exists || !isString {
+			return false, true
+		}
+		var err error
+		if pattern, err = compileJSONPathPattern(function.name, source); err != nil {
+			return false, true
+		}
+	}
+	return pattern.MatchString(input), true
+}
+
+func compileJSONPathPattern(function string, source string) (*regexp.Regexp, error) {
+	if function == "match" {
+		source = `^(?:` + source + `)$`
+	}
+	return regexp.Compile(source)
+}
+
+// parser
+
+type jsonPathParser struct {
+	src string
+	pos int
+}
+
+func (parser *jsonPathParser) error(msg string) error {
+	return fmt.Errorf("invalid JSONPath %s at %d: %s", strconv.Quote(parser.src), parser.pos, msg)
+}
+
+func (parser *jsonPathParser) peek() byte {
+	if parser.pos < len(parser.src) {
+		return parser.src[parser.pos]
+	}
+	return 0
+}
+
+func (parser *jsonPathParser) consume(c byte) bool {
+	if parser.peek() == c {
+		parser.pos++
+		return true
+	}
+	return false
+}
+
+func (parser *jsonPathParser) consumeString(str string) bool {
+	if len(parser.src)-parser.pos >= len(str) && parser.src[parser.pos:parser.pos+len(str)] == str {
+		parser.pos += len(str)
+		return true
+	}
+	return false
+}
+
+func (parser *jsonPathParser) skipBlanks() {
+	for {
+		switch parser.peek() {
+		case ' ', '\t', '\n', '\r':
+			parser.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (parser *jsonPathParser) parseSegments() ([]jsonPathSegment, error) {
+	var segments []jsonPathSegment
+	for {
+		// the blanks are allowed between segments, but may be followed by an operator in filter
+		start := parser.pos
+		parser.skipBlanks()
+		if c := parser.peek(); c != '.' && c != '[' {
+			parser.pos = start
+			return segments, nil
+		}
+		segment, err := parser.parseSegment()
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, segment)
+	}
+}
+
+func (parser *jsonPathParser) parseSegment() (jsonPathSegment, error) {
+	if parser.consumeString("..") {
+		var segment jsonPathSegment
+		var err error
+		if parser.peek() == '[' {
+			segment, err = parser.parseBracketed()
+		} else {
+			segment, err = parser.parseShorthand()
+		}
+		segment.descendant = true
+		return segment, err
+	}
+	if parser.consume('.') {
+		return parser.parseShorthand()
+	}
+	return parser.parseBracketed()
+}
+
+// parseShorthand parses * or name after . or ..
+func (parser *jsonPathParser) parseShorthand() (jsonPathSegment, error) {
+	if parser.consume('*') {
+		return jsonPathSegment{selectors: []jsonPathSelector{{kind: wildcardSelector}}}, nil
+	}
+	start := parser.pos
+	for parser.pos < len(parser.src) {
+		c := parser.src[parser.pos]
+		if c >= utf8.RuneSelf || c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' ||
+			parser.pos > start && c >= '0' && c <= '9' {
+			parser.pos++
+			continue
+		}
+		break
+	}
+	if parser.pos == start {
+		return jsonPathSegment{}, parser.error("expect name or * after .")
+	}
+	name := parser.src[start:parser.pos]
+	return jsonPathSegment{selectors: []jsonPathSelector{{kind: nameSelector, name: name}}}, nil
+}
+
+func (parser *jsonPathParser) parseBracketed() (jsonPathSegment, error) {
+	var segment jsonPathSegment
+	if !parser.consume('[') {
+		return segment, parser.error("expect [")
+	}
+	for {
+		parser.skipBlanks()
+		selector, err := parser.parseSelector()
+		if err != nil {
+			return segment, err
+		}
+		segment.selectors = append(segment.selectors, selector)
+		parser.skipBlanks()
+		if parser.consume(']') {
+			return segment, nil
+		}
+		if !parser.consume(',') {
+			return segment, parser.error("expect , or ]")
+		}
+	}
+}
+
+func (parser *jsonPathParser) parseSelector() (jsonPathSelector, error) {
+	switch c := parser.peek(); c {
+	case '\'', '"':
+		name, err := parser.parseStringLiteral()
+		return jsonPathSelector{kind: nameSelector, name: name}, err
+	case '*':
+		parser.pos++
+		return jsonPathSelector{kind: wildcardSelector}, nil
+	case '?':
+		parser.pos++
+		parser.skipBlanks()
+		filter, err := parser.parseLogicalOr()
+		return jsonPathSelector{kind: filterSelector, filter: filter}, err
+	}
+	selector := jsonPathSelector{kind: indexSelector, step: 1}
+	var err error
+	if c := parser.peek(); c == '-' || c >= '0' && c <= '9' {
+		if selector.index, err = parser.parseInt(); err != nil {
+			return selector, err
+		}
+		selector.hasStart = true
+	}
+	parser.skipBlanks()
+	if !parser.consume(':') {
+		if !selector.hasStart {
+			return selector, parser.error("expect selector")
+		}
+		return selector, nil
+	}
+	selector.kind = sliceSelector
+	parser.skipBlanks()
+	if c := parser.peek(); c == '-' || c >= '0' && c <= '9' {
+		if selector.end, err = parser.parseInt(); err != nil {
+			return selector, err
+		}
+		selector.hasEnd = true
+	}
+	parser.skipBlanks()
+	if parser.consume(':') {
+		parser.skipBlanks()
+		if c := parser.peek(); c == '-' || c >= '0' && c <= '9' {
+			if selector.step, err = parser.parseInt(); err != nil {
+				return selector, err
+			}
+		}
+	}
+	return selector, nil
+}
+
+// parseInt parses the integer of index or slice, in the range of I-JSON
+func (parser *jsonPathParser) parseInt() (int64, error) {
+	start := parser.pos
+	parser.consume('-')
+	digits := parser.pos
+	for c := parser.peek(); c >= '0' && c <= '9'; c = parser.peek() {
+		parser.pos++
+	}
+	str := parser.src[start:parser.pos]
+	if parser.pos == digits || parser.src[digits] == '0' && (parser.pos-digits > 1 || digits > start) {
+		return 0, parser.error("invalid integer " + strconv.Quote(str))
+	}
+	val, err := strconv.ParseInt(str, 10, 64)
+	if err != nil || val > maxJSONPathInt || val < -maxJSONPathInt {
+		return 0, parser.error("integer out of range " + str)
+	}
+	return val, nil
+}
+
+// parseStringLiteral parses the string quoted by ' or "
+func (parser *jsonPathParser) parseStringLiteral() (string, error) {
+	quote := parser.src[parser.pos]
+	parser.pos++
+	var str []byte
+	for parser.pos < len(parser.src) {
+		c := parser.src[parser.pos]
+		parser.pos++
+		switch {
+		case c == quote:
+			return string(str), nil
+		case c < ' ':
+			return "", parser.error("control character in string")
+		case c != '\\':
+			str = append(str, c)
+			continue
+		}
+		escape := parser.peek()
+		parser.pos++
+		switch escape {
+		case 'b':
+			str = append(str, '\b')
+		case 'f':
+			str = append(str, '\f')
+		case 'n':
+			str = append(str, '\n')
+		case 'r':
+			str = append(str, '\r')
+		case 't':
+			str = append(str, '\t')
+		case '/', '\\':
+			str = append(str, escape)
+		case 'u':
+			r, err := parser.parseHex4()
+			if err != nil {
+				return "", err
+			}
+			if utf16.IsSurrogate(r) {
+				if !parser.consumeString(`\u`) {
+					return "", parser.error("unpaired surrogate")
+				}
+				r2, err := parser.parseHex4()
+				if err != nil {
+					return "", err
+				}
+				if r = utf16.DecodeRune(r, r2); r == utf8.RuneError {
+					return "", parser.error("invalid surrogate pair")
+				}
+			}
+			str = appendRune(str, r)
+		default:
+			if escape != quote {
+				return "", parser.error("invalid escape")
+			}
+			str = append(str, escape)
+		}
+	}
+	return "", parser.error("string not terminated")
+}
+
+func (parser *jsonPathParser) parseHex4() (rune, error) {
+	if len(parser.src)-parser.pos < 4 {
+		return 0, parser.error("invalid \\u escape")
+	}
+	val, err := strconv.ParseUint(parser.src[parser.pos:parser.pos+4], 16, 32)
+	if err != nil {
+		return 0, parser.error("invalid \\u escape")
+	}
+	parser.pos += 4
+	return rune(val), nil
+}
+
+func (parser *jsonPathParser) parseLogicalOr() (jsonPathExpr, error) {
+	var operands orExpr
+	for {
+		operand, err := parser.parseLogicalAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+		start := parser.pos
+		parser.skipBlanks()
+		if !parser.consumeString("||") {
+			parser.pos = start
+			break
+		}
+		parser.skipBlanks()
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return operands, nil
+}
+
+func (parser *jsonPathParser) parseLogicalAnd() (jsonPathExpr, error) {
+	var operands andExpr
+	for {
+		operand, err := parser.parseBasic()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+		start := parser.pos
+		parser.skipBlanks()
+		if !parser.consumeString("&&") {
+			parser.pos = start
+			break
+		}
+		parser.skipBlanks()
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return operands, nil
+}
+
+func (parser *jsonPathParser) parseBasic() (jsonPathExpr, error) {
+	if parser.consume('!') {
+		parser.skipBlanks()
+		if parser.peek() == '(' {
+			operand, err := parser.parseParen()
+			return notExpr{operand}, err
+		}
+		operand, err := parser.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		test, err := parser.testOf(operand)
+		return notExpr{test}, err
+	}
+	if parser.peek() == '(' {
+		return parser.parseParen()
+	}
+	left, err := parser.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	start := parser.pos
+	parser.skipBlanks()
+	op := ""
+	for _, candidate := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if parser.consumeString(candidate) {
+			op = candidate
+			break
+		}
+	}
+	if op == "" {
+		parser.pos = start
+		return parser.testOf(left)
+	}
+	parser.skipBlanks()
+	right, err := parser.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	leftComparable, err := parser.comparableOf(left)
+	if err != nil {
+		return nil, err
+	}
+	rightComparable, err := parser.comparableOf(right)
+	if err != nil {
+		return nil, err
+	}
+	return comparisonExpr{op, leftComparable, rightComparable}, nil
+}
+
+func (parser *jsonPathParser) parseParen() (jsonPathExpr, error) {
+	parser.consume('(')
+	parser.skipBlanks()
+	expr, err := parser.parseLogicalOr()
+	if err != nil {
+		return nil, err
+	}
+	parser.skipBlanks()
+	if !parser.consume(')') {
+		return nil, parser.error("expect )")
+	}
+	return expr, nil
+}
+
+// testOf makes the test expression of query or function returning logical
+func (parser *jsonPathParser) testOf(operand interface{}) (jsonPathExpr, error) {
+	switch operand := operand.(type) {
+	case *jsonPathFilterQuery:
+		return existExpr{operand}, nil
+	case *jsonPathFunction:
+		if jsonPathFunctionTypes[operand.name][0] == "logical" {
+			return functionTestExpr{operand}, nil
+		}
+		return nil, parser.error(operand.name + "() can not be used as test")
+	}
+	return nil, parser.error("literal can not be used as test")
+}
+
+// comparableOf checks the operand of comparison is literal, singular query or function returning value
+func (parser *jsonPathParser) comparableOf(operand interface{}) (jsonPathComparable, error) {
+	switch operand := operand.(type) {
+	case *jsonPathFilterQuery:
+		if !operand.singular() {
+			return nil, parser.error("query in comparison must be singular")
+		}
+		return operand, nil
+	case *jsonPathFunction:
+		if jsonPathFunctionTypes[operand.name][0] != "value" {
+			return nil, parser.error(operand.name + "() can not be compared")
+		}
+		return operand, nil
+	}
+	return operand.(jsonPathLiteral), nil
+}
+
+// parseOperand parses literal, query or function
+func (parser *jsonPathParser) parseOperand() (interface{}, error) {
+	c := parser.peek()
+	switch {
+	case c == '@' || c == '$':
+		parser.pos++
+		segments, err := parser.parseSegments()
+		if err != nil {
+			return nil, err
+		}
+		return &jsonPathFilterQuery{c == '$', segments}, nil
+	case c == '\'' || c == '"':
+		str, err := parser.parseStringLiteral()
+		return jsonPathLiteral{str}, err
+	case c == '-' || c >= '0' && c <= '9':
+		return parser.parseNumberLiteral()
+	case parser.consumeString("true"):
+		return jsonPathLiteral{true}, nil
+	case parser.consumeString("false"):
+		return jsonPathLiteral{false}, nil
+	case parser.consumeString("null"):
+		return jsonPathLiteral{nil}, nil
+	case c >= 'a' && c <= 'z':
+		return parser.parseFunction()
+	}
+	return nil, parser.error("expect literal, query or function")
+}
+
+func (parser *jsonPathParser) parseNumberLiteral() (interface{}, error) {
+	start := parser.pos
+	for parser.pos < len(parser.src) {
+		switch parser.src[parser.pos] {
+		case '+', '-', '.', 'e', 'E', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			parser.pos++
+			continue
+		}
+		break
+	}
+	str := parser.src[start:parser.pos]
+	if !isJSONNumber([]byte(str)) {
+		return nil, parser.error("invalid number " + strconv.Quote(str))
+	}
+	val, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return nil, parser.error("invalid number " + strconv.Quote(str))
+	}
+	return jsonPathLiteral{val}, nil
+}
+
+func (parser *jsonPathParser) parseFunction() (interface{}, error) {
+	start := parser.pos
+	for c := parser.peek(); c >= 'a' && c <= 'z' || c == '_' || c >= '0' && c <= '9'; c = parser.peek() {
+		parser.pos++
+	}
+	function := &jsonPathFunction{name: parser.src[start:parser.pos]}
+	types, known := jsonPathFunctionTypes[function.name]
+	if !known {
+		return nil, parser.error("unknown function " + function.name)
+	}
+	if !parser.consume('(') {
+		return nil, parser.error("expect ( after " + function.name)
+	}
+	for i, paramType := range types[1:] {
+		parser.skipBlanks()
+		if i > 0 && !parser.consume(',') {
+			return nil, parser.error("expect , in arguments of " + function.name)
+		}
+		parser.skipBlanks()
+		operand, err := parser.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if paramType == "nodes" {
+			query, isQuery := operand.(*jsonPathFilterQuery)
+			if !isQuery {
+				return nil, parser.error("expect query in arguments of " + function.name)
+			}
+			function.args = append(function.args, query)
+			continue
+		}
+		arg, err := parser.comparableOf(operand)
+		if err != nil {
+			return nil, err
+		}
+		function.args = append(function.args, arg)
+	}
+	parser.skipBlanks()
+	if !parser.consume(')') {
+		return nil, parser.error("expect ) after arguments of " + function.name)
+	}
+	if function.name == "match" || function.name == "search" {
+		// the pattern of literal is compiled once
+		if literal, isLiteral := function.args[1].(jsonPathLiteral); isLiteral {
+			source, isString := literal.val.(string)
+			if !isString {
+				return nil, parser.error("expect string pattern in " + function.name)
+			}
+			pattern, err := compileJSONPathPattern(function.name, source)
+			if err != nil {
+				return nil, errors.New(parser.error("invalid pattern in "+function.name).Error() + ": " + err.Error())
+			}
+			function.pattern = pattern
+		}
+	}
+	return function, nil
+}
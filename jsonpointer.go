@@ -0,0 +1,111 @@
+package jsoniter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PointerError is the error of the Any returned by GetPointer, if the RFC 6901 JSON Pointer can not be resolved
+type PointerError struct {
+	Pointer string // the pointer being resolved
+	Segment int    // 0-based index of the failing reference token
+	Token   string // the failing reference token, unescaped
+	At      string // the pointer to the value the token is applied to, "" is the whole document
+	Message string
+}
+
+func (err *PointerError) Error() string {
+	return fmt.Sprintf("JSON pointer %q: token %d %q at %q: %s", err.Pointer, err.Segment, err.Token, err.At, err.Message)
+}
+
+// getPointer resolves the pointer from node, such as /items/0/name.
+// The numeric token is the index of array, or the key of object.
+func getPointer(node Any, pointer string) Any {
+	if pointer == "" {
+		return node
+	}
+	if pointer[0] != '/' {
+		return &invalidAny{baseAny{}, &PointerError{Pointer: pointer, Message: "must be empty or start with /"}}
+	}
+	start := 1
+	for segment := 0; ; segment++ {
+		end := strings.IndexByte(pointer[start:], '/')
+		if end == -1 {
+			end = len(pointer)
+		} else {
+			end += start
+		}
+		fail := func(token string, message string) Any {
+			return &invalidAny{baseAny{}, &PointerError{pointer, segment, token, pointer[:start-1], message}}
+		}
+		token, valid := unescapePointerToken(pointer[start:end])
+		if !valid {
+			return fail(pointer[start:end], "invalid ~ escape")
+		}
+		switch node.ValueType() {
+		case ObjectValue:
+			node = node.Get(token)
+			if node.ValueType() == InvalidValue {
+				return fail(token, "key not found in object")
+			}
+		case ArrayValue:
+			index, err := parsePointerIndex(token)
+			if err != "" {
+				return fail(token, err)
+			}
+			found := node.Get(index)
+			if found.ValueType() == InvalidValue {
+				return fail(token, fmt.Sprintf("index out of range of array of size %d", node.Size()))
+			}
+			node = found
+		case InvalidValue:
+			return node
+		default:
+			return fail(token, pointerValueTypeNames[node.ValueType()]+" value has no children")
+		}
+		if end == len(pointer) {
+			return node
+		}
+		start = end + 1
+	}
+}
+
+var pointerValueTypeNames = map[ValueType]string{
+	StringValue: "string",
+	NumberValue: "number",
+	NilValue:    "null",
+	BoolValue:   "bool",
+}
+
+// unescapePointerToken replaces ~1 with / and ~0 with ~
+func unescapePointerToken(token string) (string, bool) {
+	if strings.IndexByte(token, '~') == -1 {
+		return token, true
+	}
+	for i := 0; i < len(token); i++ {
+		if token[i] == '~' && (i+1 == len(token) || token[i+1] != '0' && token[i+1] != '1') {
+			return "", false
+		}
+	}
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(token), true
+}
+
+func parsePointerIndex(token string) (int, string) {
+	if token == "-" {
+		return 0, "- refers to the nonexistent element after the last one"
+	}
+	if token == "" || token[0] == '0' && len(token) > 1 {
+		return 0, "invalid array index"
+	}
+	for i := 0; i < len(token); i++ {
+		if token[i] < '0' || token[i] > '9' {
+			return 0, "invalid array index"
+		}
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, "index out of range"
+	}
+	return index, ""
+}
@@ -2,6 +2,10 @@ package misc_tests
 
 import (
 	"encoding/json"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/json-iterator/go"
@@ -93,3 +97,48 @@ func Benchmark_json_float(b *testing.B) {
 		json.Unmarshal([]byte(`1.1`), &result)
 	}
 }
+
+func Test_read_float_correctly_rounded(t *testing.T) {
+	should := require.New(t)
+	inputs := []string{"0", "1", "0.1", "1e23", "8.41e21", "5e-324", "2.2250738585072011e-308",
+		"1.7976931348623157e308", "9007199254740993", "9007199254740993.0000000001", "1.00000005960464477550",
+		"123456789012345678901234567890e-10", "7.038531e-26", "3.4028235e38", "1.401298464324817e-45",
+		"0.000000000000000000000000000000000001234", "4.9406564584124654e-324", "100000000000000016777215",
+		"1e-400", "2.4703282292062327e-324", "1.1754942807573643e-38"}
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		digits := make([]byte, 1+random.Intn(25))
+		for j := range digits {
+			digits[j] = byte('0' + random.Intn(10))
+		}
+		if digits[0] == '0' {
+			digits[0] = '1'
+		}
+		str := string(digits)
+		if dot := random.Intn(len(digits) + 1); dot > 0 && dot < len(digits) {
+			str = str[:dot] + "." + str[dot:]
+		}
+		if random.Intn(2) == 0 {
+			str += "e" + strconv.Itoa(random.Intn(700)-350)
+		}
+		inputs = append(inputs, str)
+	}
+	for _, input := range inputs {
+		expected64, err := strconv.ParseFloat(input, 64)
+		if err != nil {
+			continue
+		}
+		expected32, err32 := strconv.ParseFloat(input, 32)
+		iter := jsoniter.ParseString(jsoniter.ConfigDefault, input+",")
+		should.Equal(math.Float64bits(expected64), math.Float64bits(iter.ReadFloat64()), input)
+		should.Nil(iter.Error, input)
+		iter = jsoniter.ParseString(jsoniter.ConfigDefault, "-"+input)
+		should.Equal(math.Float64bits(-expected64), math.Float64bits(iter.ReadFloat64()), input)
+		if err32 == nil {
+			iter = jsoniter.ParseString(jsoniter.ConfigDefault, input+"]")
+			should.Equal(math.Float32bits(float32(expected32)), math.Float32bits(iter.ReadFloat32()), input)
+		}
+		iter = jsoniter.Parse(jsoniter.ConfigDefault, strings.NewReader(input+" "), 4)
+		should.Equal(math.Float64bits(expected64), math.Float64bits(iter.ReadFloat64()), input)
+	}
+}
@@ -2,6 +2,7 @@ package jsoniter
 
 import (
 	"fmt"
+	"io"
 	"reflect"
 	"unsafe"
 
@@ -76,6 +77,9 @@ func (iter *Iterator) ReadVal(obj interface{}) {
 		return
 	}
 	decoder.Decode(ptr, iter)                        // 4.执行解码 指定对应的解码后对象类型的指针以及iterator
+	if iter.Error != nil && iter.Error != io.EOF {
+		iter.addErrorType(reflect2.TypeOf(obj).(*reflect2.UnsafePtrType).Elem())
+	}
 }
 
 // WriteVal copy the go interface into underlying JSON, same as json.Marshal
@@ -148,6 +152,14 @@ func _createDecoderOfType(ctx *ctx, typ reflect2.Type) ValDecoder {
 	if decoder != nil {
 		return decoder
 	}
+	decoder = createDecoderOfDecimal(ctx, typ)
+	if decoder != nil {
+		return decoder
+	}
+	decoder = createDecoderOfBig(ctx, typ)
+	if decoder != nil {
+		return decoder
+	}
 	decoder = createDecoderOfMarshaler(ctx, typ)
 	if decoder != nil {
 		return decoder
@@ -250,6 +262,14 @@ func _createEncoderOfType(ctx *ctx, typ reflect2.Type) ValEncoder {
 	if encoder != nil {
 		return encoder
 	}
+	encoder = createEncoderOfDecimal(ctx, typ)
+	if encoder != nil {
+		return encoder
+	}
+	encoder = createEncoderOfBig(ctx, typ)
+	if encoder != nil {
+		return encoder
+	}
 	encoder = createEncoderOfMarshaler(ctx, typ)
 	if encoder != nil {
 		return encoder
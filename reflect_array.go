@@ -1,9 +1,9 @@
 package jsoniter
 
 import (
-	"fmt"
 	"github.com/modern-go/reflect2"
 	"io"
+	"strconv"
 	"unsafe"
 )
 
@@ -48,7 +48,7 @@ func (encoder *arrayEncoder) Encode(ptr unsafe.Pointer, stream *Stream) {
 	}
 	stream.WriteArrayEnd()
 	if stream.Error != nil && stream.Error != io.EOF {
-		stream.Error = fmt.Errorf("%v: %s", encoder.arrayType, stream.Error.Error())
+		stream.addErrorContext(encoder.arrayType.String() + ": ")
 	}
 }
 
@@ -64,7 +64,7 @@ type arrayDecoder struct {
 func (decoder *arrayDecoder) Decode(ptr unsafe.Pointer, iter *Iterator) {
 	decoder.doDecode(ptr, iter)
 	if iter.Error != nil && iter.Error != io.EOF {
-		iter.Error = fmt.Errorf("%v: %s", decoder.arrayType, iter.Error.Error())
+		iter.addErrorContext(decoder.arrayType.String() + ": ")
 	}
 }
 
@@ -79,23 +79,38 @@ func (decoder *arrayDecoder) doDecode(ptr unsafe.Pointer, iter *Iterator) {
 		iter.ReportError("decode array", "expect [ or n, but found "+string([]byte{c}))
 		return
 	}
+	if !iter.incrementDepth() {
+		return
+	}
+	defer iter.decrementDepth()
 	c = iter.nextToken()
 	if c == ']' {
 		return
 	}
 	iter.unreadByte()
+	mark := iter.mark()
 	elemPtr := arrayType.UnsafeGetIndex(ptr, 0)
 	decoder.elemDecoder.Decode(elemPtr, iter)
+	if iter.hasNewErrors(mark) && !iter.recoverValue(mark, arrayType.Elem(), "", "0") {
+		return
+	}
 	length := 1
 	for c = iter.nextToken(); c == ','; c = iter.nextToken() {
-		if length >= arrayType.Len() {
+		idx := length
+		length += 1
+		if !iter.checkArrayElements(length) {
+			return
+		}
+		if idx >= arrayType.Len() {
 			iter.Skip()
 			continue
 		}
-		idx := length
-		length += 1
+		mark = iter.mark()
 		elemPtr = arrayType.UnsafeGetIndex(ptr, idx)
 		decoder.elemDecoder.Decode(elemPtr, iter)
+		if iter.hasNewErrors(mark) && !iter.recoverValue(mark, arrayType.Elem(), "", strconv.Itoa(idx)) {
+			return
+		}
 	}
 	if c != ']' {
 		iter.ReportError("decode array", "expect ], but found "+string([]byte{c}))
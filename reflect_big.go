@@ -0,0 +1,148 @@
+package jsoniter
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"unsafe"
+
+	"github.com/modern-go/reflect2"
+)
+
+var bigIntType = reflect2.TypeOfPtr((*big.Int)(nil)).Elem()
+var bigFloatType = reflect2.TypeOfPtr((*big.Float)(nil)).Elem()
+var bigRatType = reflect2.TypeOfPtr((*big.Rat)(nil)).Elem()
+
+// big.Int, big.Float and big.Rat are encoded as JSON numbers without losing precision,
+// both numbers and strings are decoded. With BigNumbersAsStrings, the codecs are not used,
+// they are encoded and decoded by MarshalJSON and MarshalText as encoding/json does.
+
+func createDecoderOfBig(ctx *ctx, typ reflect2.Type) ValDecoder {
+	if ctx.bigNumbersAsStrings {
+		return nil
+	}
+	switch typ {
+	case bigIntType, bigFloatType, bigRatType:
+		return &bigNumberCodec{typ}
+	}
+	return nil
+}
+
+func createEncoderOfBig(ctx *ctx, typ reflect2.Type) ValEncoder {
+	if ctx.bigNumbersAsStrings {
+		return nil
+	}
+	switch typ {
+	case bigIntType, bigFloatType, bigRatType:
+		return &bigNumberCodec{typ}
+	}
+	if typ.Kind() == reflect.Ptr {
+		// the pointers implement json.Marshaler or encoding.TextMarshaler, which should not be used
+		if encoder := createEncoderOfBig(ctx, typ.(*reflect2.UnsafePtrType).Elem()); encoder != nil {
+			return &OptionalEncoder{encoder}
+		}
+	}
+	return nil
+}
+
+type bigNumberCodec struct {
+	typ reflect2.Type
+}
+
+func (codec *bigNumberCodec) Decode(ptr unsafe.Pointer, iter *Iterator) {
+	var str string
+	// the strings are parsed the same as UnmarshalText, such as 0x1f or 1/3
+	base := 0
+	switch iter.WhatIsNext() {
+	case NilValue:
+		iter.skipFourBytes('n', 'u', 'l', 'l')
+		return
+	case StringValue:
+		str = iter.ReadString()
+	case NumberValue:
+		str = iter.readNumberAsString()
+		base = 10
+	default:
+		iter.ReportError("bigNumberCodec", "expect number or string for "+codec.typ.String())
+		return
+	}
+	if iter.Error != nil && iter.Error != io.EOF {
+		return
+	}
+	var ok bool
+	switch codec.typ {
+	case bigIntType:
+		_, ok = (*big.Int)(ptr).SetString(str, base)
+	case bigFloatType:
+		val := (*big.Float)(ptr)
+		if val.Prec() == 0 {
+			// enough bits for every digit, 4 bits are more than log2(10)
+			prec := uint(len(str)) * 4
+			if prec < 64 {
+				prec = 64
+			}
+			val.SetPrec(prec)
+		}
+		_, ok = val.SetString(str)
+	case bigRatType:
+		_, ok = (*big.Rat)(ptr).SetString(str)
+	}
+	if !ok {
+		iter.ReportError("bigNumberCodec", fmt.Sprintf("invalid %s: %q", codec.typ.String(), str))
+	}
+}
+
+func (codec *bigNumberCodec) Encode(ptr unsafe.Pointer, stream *Stream) {
+	var str string
+	switch codec.typ {
+	case bigIntType:
+		str = (*big.Int)(ptr).String()
+	case bigFloatType:
+		val := (*big.Float)(ptr)
+		if val.IsInf() {
+			stream.Error = fmt.Errorf("unsupported value: %s", val.String())
+			return
+		}
+		str = val.Text('g', -1)
+	case bigRatType:
+		val := (*big.Rat)(ptr)
+		places, exact := decimalPlacesOfRat(val)
+		if !exact {
+			stream.Error = fmt.Errorf("unsupported value: %s has no exact decimal representation", val.String())
+			return
+		}
+		str = val.FloatString(places)
+	}
+	stream.WriteRaw(str)
+}
+
+// decimalPlacesOfRat returns the decimal places to write the rational number exactly,
+// the denominator must be a product of 2s and 5s
+func decimalPlacesOfRat(val *big.Rat) (int, bool) {
+	if val.IsInt() {
+		return 0, true
+	}
+	denom := new(big.Int).Set(val.Denom())
+	twos := int(denom.TrailingZeroBits())
+	denom.Rsh(denom, uint(twos))
+	fives := 0
+	five := big.NewInt(5)
+	remainder := new(big.Int)
+	for denom.Cmp(big.NewInt(1)) != 0 {
+		quotient, _ := new(big.Int).QuoRem(denom, five, remainder)
+		if remainder.Sign() != 0 {
+			return 0, false
+		}
+		denom = quotient
+		fives++
+	}
+	if twos > fives {
+		return twos, true
+	}
+	return fives, true
+}
+
+func (codec *bigNumberCodec) IsEmpty(ptr unsafe.Pointer) bool {
+	return false
+}
@@ -354,7 +354,7 @@ func describeStruct(ctx *ctx, typ reflect2.Type) *StructDescriptor {
 					binding.levels = append([]int{i}, binding.levels...)
 					omitempty := binding.Encoder.(*structFieldEncoder).omitempty
 					binding.Encoder = &structFieldEncoder{field, binding.Encoder, omitempty}
-					binding.Decoder = &structFieldDecoder{field, binding.Decoder}
+					binding.Decoder = &structFieldDecoder{field, binding.Decoder, ""}
 					embeddedBindings = append(embeddedBindings, binding)
 				}
 				continue
@@ -368,7 +368,7 @@ func describeStruct(ctx *ctx, typ reflect2.Type) *StructDescriptor {
 						binding.Encoder = &dereferenceEncoder{binding.Encoder}
 						binding.Encoder = &structFieldEncoder{field, binding.Encoder, omitempty}
 						binding.Decoder = &dereferenceDecoder{ptrType.Elem(), binding.Decoder}
-						binding.Decoder = &structFieldDecoder{field, binding.Decoder}
+						binding.Decoder = &structFieldDecoder{field, binding.Decoder, ""}
 						embeddedBindings = append(embeddedBindings, binding)
 					}
 					continue
@@ -459,11 +459,19 @@ func processTags(structDescriptor *StructDescriptor, cfg *frozenConfig) {
 				}
 			}
 		}
-		binding.Decoder = &structFieldDecoder{binding.Field, binding.Decoder}
+		binding.Decoder = &structFieldDecoder{binding.Field, binding.Decoder, bindingName(binding)}
 		binding.Encoder = &structFieldEncoder{binding.Field, binding.Encoder, shouldOmitEmpty}
 	}
 }
 
+// bindingName is the name of the field in JSON, used to locate the field in errors
+func bindingName(binding *Binding) string {
+	if len(binding.FromNames) > 0 {
+		return binding.FromNames[0]
+	}
+	return binding.Field.Name()
+}
+
 func calcFieldNames(originalFieldName string, tagProvidedFieldName string, wholeTag string) []string {
 	// ignore?
 	if wholeTag == "-" {
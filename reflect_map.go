@@ -159,55 +159,100 @@ func (decoder *mapDecoder) Decode(ptr unsafe.Pointer, iter *Iterator) {
 		iter.ReportError("ReadMapCB", `expect { or n, but found `+string([]byte{c}))
 		return
 	}
+	if !iter.incrementDepth() {
+		return
+	}
+	defer iter.decrementDepth()
 	c = iter.nextToken()
 	if c == '}' {
 		return
 	}
-	if c != '"' {
+	if c != '"' && !iter.cfg.relaxed {
 		iter.ReportError("ReadMapCB", `expect " after }, but found `+string([]byte{c}))
 		return
 	}
 	iter.unreadByte()
-	key := decoder.keyType.UnsafeNew()
-	decoder.keyDecoder.Decode(key, iter)
-	c = iter.nextToken()
-	if c != ':' {
-		iter.ReportError("ReadMapCB", "expect : after object field, but found "+string([]byte{c}))
+	keys := iter.newObjectKeys()
+	if !decoder.decodeMember(ptr, iter, &keys) {
 		return
 	}
-	elem := decoder.elemType.UnsafeNew()
-	decoder.elemDecoder.Decode(elem, iter)
-	decoder.mapType.UnsafeSetIndex(ptr, key, elem)
-	for c = iter.nextToken(); c == ','; c = iter.nextToken() {
-		key := decoder.keyType.UnsafeNew()
-		decoder.keyDecoder.Decode(key, iter)
-		c = iter.nextToken()
-		if c != ':' {
-			iter.ReportError("ReadMapCB", "expect : after object field, but found "+string([]byte{c}))
+	c = iter.nextToken()
+	for members := 2; c == ','; members++ {
+		if !iter.checkObjectMembers(members) {
+			return
+		}
+		if c = iter.nextToken(); c != '"' && !iter.cfg.relaxed {
+			iter.ReportError("ReadMapCB", `expect " after ,, but found `+string([]byte{c}))
+			return
+		}
+		iter.unreadByte()
+		if !decoder.decodeMember(ptr, iter, &keys) {
 			return
 		}
-		elem := decoder.elemType.UnsafeNew()
-		decoder.elemDecoder.Decode(elem, iter)
-		decoder.mapType.UnsafeSetIndex(ptr, key, elem)
+		c = iter.nextToken()
 	}
 	if c != '}' {
 		iter.ReportError("ReadMapCB", `expect }, but found `+string([]byte{c}))
+		return
 	}
 }
 
+// decodeMember decodes one key and value into the map, it returns false to stop
+func (decoder *mapDecoder) decodeMember(ptr unsafe.Pointer, iter *Iterator, keys *objectKeys) bool {
+	key := decoder.keyType.UnsafeNew()
+	if iter.nextIsUnquotedKey() {
+		decoder.decodeUnquotedKey(key, iter)
+	} else {
+		decoder.keyDecoder.Decode(key, iter)
+	}
+	c := iter.nextToken()
+	if c != ':' {
+		iter.ReportError("ReadMapCB", "expect : after object field, but found "+string([]byte{c}))
+		return false
+	}
+	if keys.policy != DuplicateKeysDefault && keys.skip(iter, decoder.keyType.UnsafeIndirect(key)) {
+		iter.Skip()
+		return iter.Error == nil || iter.Error == io.EOF
+	}
+	mark := iter.mark()
+	elem := decoder.elemType.UnsafeNew()
+	decoder.elemDecoder.Decode(elem, iter)
+	if iter.hasNewErrors(mark) && !iter.recoverValue(mark, decoder.elemType, "", decoder.keyToken(key)) {
+		return false
+	}
+	decoder.mapType.UnsafeSetIndex(ptr, key, elem)
+	return true
+}
+
+// decodeUnquotedKey decodes the unquoted key of relaxed mode the same as the quoted one
+func (decoder *mapDecoder) decodeUnquotedKey(key unsafe.Pointer, iter *Iterator) {
+	field := iter.readUnquotedKey()
+	keyIter := iter.cfg.BorrowIterator([]byte(`"` + field + `"`))
+	defer iter.cfg.ReturnIterator(keyIter)
+	decoder.keyDecoder.Decode(key, keyIter)
+	if keyIter.Error != nil && keyIter.Error != io.EOF {
+		iter.ReportError("ReadMapCB", keyIter.Error.Error())
+	}
+}
+
+// keyToken formats the decoded key as JSON pointer reference token
+func (decoder *mapDecoder) keyToken(key unsafe.Pointer) string {
+	return fmt.Sprint(decoder.keyType.UnsafeIndirect(key))
+}
+
 type numericMapKeyDecoder struct {
 	decoder ValDecoder
 }
 
 func (decoder *numericMapKeyDecoder) Decode(ptr unsafe.Pointer, iter *Iterator) {
-	c := iter.nextToken()
-	if c != '"' {
-		iter.ReportError("ReadMapCB", `expect ", but found `+string([]byte{c}))
+	quote := iter.nextToken()
+	if quote != '"' && !(quote == '\'' && iter.cfg.relaxed) {
+		iter.ReportError("ReadMapCB", `expect ", but found `+string([]byte{quote}))
 		return
 	}
 	decoder.decoder.Decode(ptr, iter)
-	c = iter.nextToken()
-	if c != '"' {
+	c := iter.nextToken()
+	if c != quote {
 		iter.ReportError("ReadMapCB", `expect ", but found `+string([]byte{c}))
 		return
 	}
@@ -4,6 +4,7 @@ import (
 	"encoding"
 	"encoding/json"
 	"github.com/modern-go/reflect2"
+	"reflect"
 	"unsafe"
 )
 
@@ -96,7 +97,7 @@ func (encoder *marshalerEncoder) Encode(ptr unsafe.Pointer, stream *Stream) {
 	marshaler := obj.(json.Marshaler)
 	bytes, err := marshaler.MarshalJSON()
 	if err != nil {
-		stream.Error = err
+		stream.Error = stream.marshalerError(obj, err)
 	} else {
 		stream.Write(bytes)
 	}
@@ -118,7 +119,7 @@ func (encoder *directMarshalerEncoder) Encode(ptr unsafe.Pointer, stream *Stream
 	}
 	bytes, err := marshaler.MarshalJSON()
 	if err != nil {
-		stream.Error = err
+		stream.Error = stream.marshalerError(marshaler, err)
 	} else {
 		stream.Write(bytes)
 	}
@@ -143,7 +144,7 @@ func (encoder *textMarshalerEncoder) Encode(ptr unsafe.Pointer, stream *Stream)
 	marshaler := (obj).(encoding.TextMarshaler)
 	bytes, err := marshaler.MarshalText()
 	if err != nil {
-		stream.Error = err
+		stream.Error = stream.marshalerError(obj, err)
 	} else {
 		str := string(bytes)
 		encoder.stringEncoder.Encode(unsafe.Pointer(&str), stream)
@@ -167,7 +168,7 @@ func (encoder *directTextMarshalerEncoder) Encode(ptr unsafe.Pointer, stream *St
 	}
 	bytes, err := marshaler.MarshalText()
 	if err != nil {
-		stream.Error = err
+		stream.Error = stream.marshalerError(marshaler, err)
 	} else {
 		str := string(bytes)
 		encoder.stringEncoder.Encode(unsafe.Pointer(&str), stream)
@@ -178,6 +179,15 @@ func (encoder *directTextMarshalerEncoder) IsEmpty(ptr unsafe.Pointer) bool {
 	return encoder.checkIsEmpty.IsEmpty(ptr)
 }
 
+// marshalerError wraps the error returned by MarshalJSON or MarshalText as *json.MarshalerError,
+// if the config asks for errors compatible with standard library
+func (stream *Stream) marshalerError(obj interface{}, err error) error {
+	if !stream.cfg.standardLibraryErrors {
+		return err
+	}
+	return &json.MarshalerError{Type: reflect.TypeOf(obj), Err: err}
+}
+
 type unmarshalerDecoder struct {
 	valType reflect2.Type
 }
@@ -191,7 +201,7 @@ func (decoder *unmarshalerDecoder) Decode(ptr unsafe.Pointer, iter *Iterator) {
 	bytes := iter.SkipAndReturnBytes()
 	err := unmarshaler.UnmarshalJSON(bytes)
 	if err != nil {
-		iter.ReportError("unmarshalerDecoder", err.Error())
+		iter.reportUnderlyingError("unmarshalerDecoder", err)
 	}
 }
 
@@ -213,6 +223,6 @@ func (decoder *textUnmarshalerDecoder) Decode(ptr unsafe.Pointer, iter *Iterator
 	str := iter.ReadString()
 	err := unmarshaler.UnmarshalText([]byte(str))
 	if err != nil {
-		iter.ReportError("textUnmarshalerDecoder", err.Error())
+		iter.reportUnderlyingError("textUnmarshalerDecoder", err)
 	}
 }
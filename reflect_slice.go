@@ -1,9 +1,9 @@
 package jsoniter
 
 import (
-	"fmt"
 	"github.com/modern-go/reflect2"
 	"io"
+	"strconv"
 	"unsafe"
 )
 
@@ -43,7 +43,7 @@ func (encoder *sliceEncoder) Encode(ptr unsafe.Pointer, stream *Stream) {
 	}
 	stream.WriteArrayEnd()
 	if stream.Error != nil && stream.Error != io.EOF {
-		stream.Error = fmt.Errorf("%v: %s", encoder.sliceType, stream.Error.Error())
+		stream.addErrorContext(encoder.sliceType.String() + ": ")
 	}
 }
 
@@ -59,11 +59,14 @@ type sliceDecoder struct {
 func (decoder *sliceDecoder) Decode(ptr unsafe.Pointer, iter *Iterator) {
 	decoder.doDecode(ptr, iter)
 	if iter.Error != nil && iter.Error != io.EOF {
-		iter.Error = fmt.Errorf("%v: %s", decoder.sliceType, iter.Error.Error())
+		iter.addErrorContext(decoder.sliceType.String() + ": ")
 	}
 }
 
 func (decoder *sliceDecoder) doDecode(ptr unsafe.Pointer, iter *Iterator) {
+	if decoder.decodeInParallel(ptr, iter) {
+		return
+	}
 	c := iter.nextToken()
 	sliceType := decoder.sliceType
 	if c == 'n' {
@@ -75,6 +78,10 @@ func (decoder *sliceDecoder) doDecode(ptr unsafe.Pointer, iter *Iterator) {
 		iter.ReportError("decode slice", "expect [ or n, but found "+string([]byte{c}))
 		return
 	}
+	if !iter.incrementDepth() {
+		return
+	}
+	defer iter.decrementDepth()
 	c = iter.nextToken()
 	if c == ']' {
 		sliceType.UnsafeSet(ptr, sliceType.UnsafeMakeSlice(0, 0))
@@ -82,15 +89,26 @@ func (decoder *sliceDecoder) doDecode(ptr unsafe.Pointer, iter *Iterator) {
 	}
 	iter.unreadByte()
 	sliceType.UnsafeGrow(ptr, 1)
+	mark := iter.mark()
 	elemPtr := sliceType.UnsafeGetIndex(ptr, 0)
 	decoder.elemDecoder.Decode(elemPtr, iter)
+	if iter.hasNewErrors(mark) && !iter.recoverValue(mark, sliceType.Elem(), "", "0") {
+		return
+	}
 	length := 1
 	for c = iter.nextToken(); c == ','; c = iter.nextToken() {
 		idx := length
 		length += 1
+		if !iter.checkArrayElements(length) {
+			return
+		}
 		sliceType.UnsafeGrow(ptr, length)
+		mark = iter.mark()
 		elemPtr = sliceType.UnsafeGetIndex(ptr, idx)
 		decoder.elemDecoder.Decode(elemPtr, iter)
+		if iter.hasNewErrors(mark) && !iter.recoverValue(mark, sliceType.Elem(), "", strconv.Itoa(idx)) {
+			return
+		}
 	}
 	if c != ']' {
 		iter.ReportError("decode slice", "expect ], but found "+string([]byte{c}))
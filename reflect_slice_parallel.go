@@ -0,0 +1,139 @@
+package jsoniter
+
+import (
+	"io"
+	"sync"
+	"unsafe"
+)
+
+const defaultParallelMinBytes = 1 << 20
+
+// decodeInParallel decodes the slice with ParallelDecodeWorkers goroutines,
+// if the input is []byte and the array is large enough.
+// The elements are counted by Skip first, without keeping where they are,
+// so that the arrays too small, and the arrays inside them, cost no more than skipping once.
+// Then the element boundaries are found, and every element is decoded by its own Iterator.
+// It returns false if the slice should be decoded sequentially,
+// in this case the iterator is left at where it was.
+func (decoder *sliceDecoder) decodeInParallel(ptr unsafe.Pointer, iter *Iterator) bool {
+	cfg := iter.cfg
+	if cfg.parallelDecodeWorkers < 2 || iter.reader != nil || iter.inParallelWorker ||
+		cfg.collectAllErrors || iter.tail-iter.head < cfg.parallelDecodeMinBytes ||
+		iter.head < iter.smallArrayEnd || iter.Error != nil {
+		return false
+	}
+	start := iter.head
+	length := 0
+	iter.ReadArrayCB(func(iter *Iterator) bool {
+		length++
+		iter.Skip()
+		return iter.Error == nil || iter.Error == io.EOF
+	})
+	if iter.Error != nil && iter.Error != io.EOF || length < cfg.parallelDecodeWorkers ||
+		iter.head-start < cfg.parallelDecodeMinBytes {
+		if (iter.Error == nil || iter.Error == io.EOF) && iter.head-start < cfg.parallelDecodeMinBytes {
+			iter.smallArrayEnd = iter.head
+		}
+		// the errors are reported by decoding sequentially, to find the same error at the same element
+		iter.Error = nil
+		iter.head = start
+		return false
+	}
+	iter.head = start
+	starts, ends := iter.scanArrayElements(length)
+	if iter.Error != nil && iter.Error != io.EOF {
+		iter.Error = nil
+		iter.head = start
+		return false
+	}
+	sliceType := decoder.sliceType
+	// grow the same way as sequential decoding, so that the existing elements are reused the same way
+	if capacity := sliceType.UnsafeCap(ptr); capacity < length {
+		sliceType.UnsafeGrow(ptr, capacity)
+	}
+	sliceType.UnsafeGrow(ptr, length)
+	chunkSize := length / (cfg.parallelDecodeWorkers * 4)
+	if chunkSize < 1 {
+		chunkSize = 1
+	}
+	chunks := make(chan int, length/chunkSize+1)
+	for chunkStart := 0; chunkStart < length; chunkStart += chunkSize {
+		chunks <- chunkStart
+	}
+	close(chunks)
+	var failed bool
+	var failedLock sync.Mutex
+	var workers sync.WaitGroup
+	for i := 0; i < cfg.parallelDecodeWorkers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			subIter := cfg.BorrowIterator(nil)
+			subIter.inParallelWorker = true
+			subIter.Attachment = iter.Attachment
+			defer func() {
+				subIter.inParallelWorker = false
+				cfg.ReturnIterator(subIter)
+			}()
+			for chunkStart := range chunks {
+				chunkEnd := chunkStart + chunkSize
+				if chunkEnd > length {
+					chunkEnd = length
+				}
+				for idx := chunkStart; idx < chunkEnd; idx++ {
+					subIter.ResetBytes(iter.buf[starts[idx]:ends[idx]])
+					subIter.depth = iter.depth + 1
+					decoder.elemDecoder.Decode(sliceType.UnsafeGetIndex(ptr, idx), subIter)
+					if subIter.Error != nil && subIter.Error != io.EOF {
+						failedLock.Lock()
+						failed = true
+						failedLock.Unlock()
+						return
+					}
+				}
+			}
+		}()
+	}
+	workers.Wait()
+	if failed {
+		iter.head = start
+		return false
+	}
+	return true
+}
+
+// scanArrayElements skips the array of length elements, and returns where every element starts and ends
+func (iter *Iterator) scanArrayElements(length int) (starts []int, ends []int) {
+	c := iter.nextToken()
+	if c != '[' {
+		iter.ReportError("scanArrayElements", "expect [, but found "+string([]byte{c}))
+		return
+	}
+	if !iter.incrementDepth() {
+		return
+	}
+	defer iter.decrementDepth()
+	c = iter.nextToken()
+	if c == ']' {
+		return
+	}
+	iter.unreadByte()
+	starts = make([]int, 0, length)
+	ends = make([]int, 0, length)
+	for {
+		if !iter.checkArrayElements(len(starts) + 1) {
+			return
+		}
+		starts = append(starts, iter.head)
+		iter.Skip()
+		ends = append(ends, iter.head)
+		c = iter.nextToken()
+		if c == ']' {
+			return
+		}
+		if c != ',' {
+			iter.ReportError("scanArrayElements", "expect , or ], but found "+string([]byte{c}))
+			return
+		}
+	}
+}
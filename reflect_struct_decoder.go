@@ -1,7 +1,6 @@
 package jsoniter
 
 import (
-	"fmt"
 	"io"
 	"strings"
 	"unsafe"
@@ -45,8 +44,9 @@ func decoderOfStruct(ctx *ctx, typ reflect2.Type) ValDecoder {
 }
 
 func createStructDecoder(ctx *ctx, typ reflect2.Type, fields map[string]*structFieldDecoder) ValDecoder {
-	if ctx.disallowUnknownFields {
-		return &generalStructDecoder{typ: typ, fields: fields, disallowUnknownFields: true}
+	if ctx.disallowUnknownFields || ctx.duplicateKeys != DuplicateKeysDefault {
+		// the specialized decoders match the fields by hash, without the name to find the duplicates
+		return &generalStructDecoder{typ: typ, fields: fields, disallowUnknownFields: ctx.disallowUnknownFields}
 	}
 	knownHash := map[int64]struct{}{
 		0: {},
@@ -500,22 +500,25 @@ func (decoder *generalStructDecoder) Decode(ptr unsafe.Pointer, iter *Iterator)
 	if !iter.readObjectStart() {
 		return
 	}
-	var c byte
-	for c = ','; c == ','; c = iter.nextToken() {
-		decoder.decodeOneField(ptr, iter)
+	c := byte(',')
+	keys := iter.newObjectKeys()
+	for members := 1; c == ',' && iter.checkObjectMembers(members); members++ {
+		decoder.decodeOneField(ptr, iter, &keys)
+		c = iter.nextToken()
 	}
+	iter.decrementDepth()
 	if iter.Error != nil && iter.Error != io.EOF {
-		iter.Error = fmt.Errorf("%v.%s", decoder.typ, iter.Error.Error())
+		iter.addStructErrorContext(decoder.typ)
 	}
 	if c != '}' {
 		iter.ReportError("struct Decode", `expect }, but found `+string([]byte{c}))
 	}
 }
 
-func (decoder *generalStructDecoder) decodeOneField(ptr unsafe.Pointer, iter *Iterator) {
+func (decoder *generalStructDecoder) decodeOneField(ptr unsafe.Pointer, iter *Iterator, keys *objectKeys) {
 	var field string
 	var fieldDecoder *structFieldDecoder
-	if iter.cfg.objectFieldMustBeSimpleString {
+	if iter.cfg.objectFieldMustBeSimpleString && !iter.cfg.relaxed {
 		fieldBytes := iter.ReadStringAsSlice()
 		field = *(*string)(unsafe.Pointer(&fieldBytes))
 		fieldDecoder = decoder.fields[field]
@@ -523,12 +526,30 @@ func (decoder *generalStructDecoder) decodeOneField(ptr unsafe.Pointer, iter *It
 			fieldDecoder = decoder.fields[strings.ToLower(field)]
 		}
 	} else {
-		field = iter.ReadString()
+		field = iter.readObjectKey()
 		fieldDecoder = decoder.fields[field]
 		if fieldDecoder == nil && !iter.cfg.caseSensitive {
 			fieldDecoder = decoder.fields[strings.ToLower(field)]
 		}
 	}
+	if keys.policy != DuplicateKeysDefault {
+		key := field
+		if iter.cfg.objectFieldMustBeSimpleString {
+			// the field aliases the buffer of iterator
+			key = string(append([]byte(nil), field...))
+		}
+		if !iter.cfg.caseSensitive {
+			key = strings.ToLower(key)
+		}
+		if keys.skip(iter, key) {
+			c := iter.nextToken()
+			if c != ':' {
+				iter.ReportError("ReadObject", "expect : after object field, but found "+string([]byte{c}))
+			}
+			iter.Skip()
+			return
+		}
+	}
 	if fieldDecoder == nil {
 		msg := "found unknown field: " + field
 		if decoder.disallowUnknownFields {
@@ -571,7 +592,7 @@ func (decoder *oneFieldStructDecoder) Decode(ptr unsafe.Pointer, iter *Iterator)
 	if !iter.readObjectStart() {
 		return
 	}
-	for {
+	for members := 1; iter.checkObjectMembers(members); members++ {
 		if iter.readFieldHash() == decoder.fieldHash {
 			decoder.fieldDecoder.Decode(ptr, iter)
 		} else {
@@ -581,8 +602,9 @@ func (decoder *oneFieldStructDecoder) Decode(ptr unsafe.Pointer, iter *Iterator)
 			break
 		}
 	}
+	iter.decrementDepth()
 	if iter.Error != nil && iter.Error != io.EOF {
-		iter.Error = fmt.Errorf("%v.%s", decoder.typ, iter.Error.Error())
+		iter.addStructErrorContext(decoder.typ)
 	}
 }
 
@@ -598,7 +620,7 @@ func (decoder *twoFieldsStructDecoder) Decode(ptr unsafe.Pointer, iter *Iterator
 	if !iter.readObjectStart() {
 		return
 	}
-	for {
+	for members := 1; iter.checkObjectMembers(members); members++ {
 		switch iter.readFieldHash() {
 		case decoder.fieldHash1:
 			decoder.fieldDecoder1.Decode(ptr, iter)
@@ -611,8 +633,9 @@ func (decoder *twoFieldsStructDecoder) Decode(ptr unsafe.Pointer, iter *Iterator
 			break
 		}
 	}
+	iter.decrementDepth()
 	if iter.Error != nil && iter.Error != io.EOF {
-		iter.Error = fmt.Errorf("%v.%s", decoder.typ, iter.Error.Error())
+		iter.addStructErrorContext(decoder.typ)
 	}
 }
 
@@ -630,7 +653,7 @@ func (decoder *threeFieldsStructDecoder) Decode(ptr unsafe.Pointer, iter *Iterat
 	if !iter.readObjectStart() {
 		return
 	}
-	for {
+	for members := 1; iter.checkObjectMembers(members); members++ {
 		switch iter.readFieldHash() {
 		case decoder.fieldHash1:
 			decoder.fieldDecoder1.Decode(ptr, iter)
@@ -645,8 +668,9 @@ func (decoder *threeFieldsStructDecoder) Decode(ptr unsafe.Pointer, iter *Iterat
 			break
 		}
 	}
+	iter.decrementDepth()
 	if iter.Error != nil && iter.Error != io.EOF {
-		iter.Error = fmt.Errorf("%v.%s", decoder.typ, iter.Error.Error())
+		iter.addStructErrorContext(decoder.typ)
 	}
 }
 
@@ -666,7 +690,7 @@ func (decoder *fourFieldsStructDecoder) Decode(ptr unsafe.Pointer, iter *Iterato
 	if !iter.readObjectStart() {
 		return
 	}
-	for {
+	for members := 1; iter.checkObjectMembers(members); members++ {
 		switch iter.readFieldHash() {
 		case decoder.fieldHash1:
 			decoder.fieldDecoder1.Decode(ptr, iter)
@@ -683,8 +707,9 @@ func (decoder *fourFieldsStructDecoder) Decode(ptr unsafe.Pointer, iter *Iterato
 			break
 		}
 	}
+	iter.decrementDepth()
 	if iter.Error != nil && iter.Error != io.EOF {
-		iter.Error = fmt.Errorf("%v.%s", decoder.typ, iter.Error.Error())
+		iter.addStructErrorContext(decoder.typ)
 	}
 }
 
@@ -706,7 +731,7 @@ func (decoder *fiveFieldsStructDecoder) Decode(ptr unsafe.Pointer, iter *Iterato
 	if !iter.readObjectStart() {
 		return
 	}
-	for {
+	for members := 1; iter.checkObjectMembers(members); members++ {
 		switch iter.readFieldHash() {
 		case decoder.fieldHash1:
 			decoder.fieldDecoder1.Decode(ptr, iter)
@@ -725,8 +750,9 @@ func (decoder *fiveFieldsStructDecoder) Decode(ptr unsafe.Pointer, iter *Iterato
 			break
 		}
 	}
+	iter.decrementDepth()
 	if iter.Error != nil && iter.Error != io.EOF {
-		iter.Error = fmt.Errorf("%v.%s", decoder.typ, iter.Error.Error())
+		iter.addStructErrorContext(decoder.typ)
 	}
 }
 
@@ -750,7 +776,7 @@ func (decoder *sixFieldsStructDecoder) Decode(ptr unsafe.Pointer, iter *Iterator
 	if !iter.readObjectStart() {
 		return
 	}
-	for {
+	for members := 1; iter.checkObjectMembers(members); members++ {
 		switch iter.readFieldHash() {
 		case decoder.fieldHash1:
 			decoder.fieldDecoder1.Decode(ptr, iter)
@@ -771,8 +797,9 @@ func (decoder *sixFieldsStructDecoder) Decode(ptr unsafe.Pointer, iter *Iterator
 			break
 		}
 	}
+	iter.decrementDepth()
 	if iter.Error != nil && iter.Error != io.EOF {
-		iter.Error = fmt.Errorf("%v.%s", decoder.typ, iter.Error.Error())
+		iter.addStructErrorContext(decoder.typ)
 	}
 }
 
@@ -798,7 +825,7 @@ func (decoder *sevenFieldsStructDecoder) Decode(ptr unsafe.Pointer, iter *Iterat
 	if !iter.readObjectStart() {
 		return
 	}
-	for {
+	for members := 1; iter.checkObjectMembers(members); members++ {
 		switch iter.readFieldHash() {
 		case decoder.fieldHash1:
 			decoder.fieldDecoder1.Decode(ptr, iter)
@@ -821,8 +848,9 @@ func (decoder *sevenFieldsStructDecoder) Decode(ptr unsafe.Pointer, iter *Iterat
 			break
 		}
 	}
+	iter.decrementDepth()
 	if iter.Error != nil && iter.Error != io.EOF {
-		iter.Error = fmt.Errorf("%v.%s", decoder.typ, iter.Error.Error())
+		iter.addStructErrorContext(decoder.typ)
 	}
 }
 
@@ -850,7 +878,7 @@ func (decoder *eightFieldsStructDecoder) Decode(ptr unsafe.Pointer, iter *Iterat
 	if !iter.readObjectStart() {
 		return
 	}
-	for {
+	for members := 1; iter.checkObjectMembers(members); members++ {
 		switch iter.readFieldHash() {
 		case decoder.fieldHash1:
 			decoder.fieldDecoder1.Decode(ptr, iter)
@@ -875,8 +903,9 @@ func (decoder *eightFieldsStructDecoder) Decode(ptr unsafe.Pointer, iter *Iterat
 			break
 		}
 	}
+	iter.decrementDepth()
 	if iter.Error != nil && iter.Error != io.EOF {
-		iter.Error = fmt.Errorf("%v.%s", decoder.typ, iter.Error.Error())
+		iter.addStructErrorContext(decoder.typ)
 	}
 }
 
@@ -906,7 +935,7 @@ func (decoder *nineFieldsStructDecoder) Decode(ptr unsafe.Pointer, iter *Iterato
 	if !iter.readObjectStart() {
 		return
 	}
-	for {
+	for members := 1; iter.checkObjectMembers(members); members++ {
 		switch iter.readFieldHash() {
 		case decoder.fieldHash1:
 			decoder.fieldDecoder1.Decode(ptr, iter)
@@ -933,8 +962,9 @@ func (decoder *nineFieldsStructDecoder) Decode(ptr unsafe.Pointer, iter *Iterato
 			break
 		}
 	}
+	iter.decrementDepth()
 	if iter.Error != nil && iter.Error != io.EOF {
-		iter.Error = fmt.Errorf("%v.%s", decoder.typ, iter.Error.Error())
+		iter.addStructErrorContext(decoder.typ)
 	}
 }
 
@@ -966,7 +996,7 @@ func (decoder *tenFieldsStructDecoder) Decode(ptr unsafe.Pointer, iter *Iterator
 	if !iter.readObjectStart() {
 		return
 	}
-	for {
+	for members := 1; iter.checkObjectMembers(members); members++ {
 		switch iter.readFieldHash() {
 		case decoder.fieldHash1:
 			decoder.fieldDecoder1.Decode(ptr, iter)
@@ -995,21 +1025,29 @@ func (decoder *tenFieldsStructDecoder) Decode(ptr unsafe.Pointer, iter *Iterator
 			break
 		}
 	}
+	iter.decrementDepth()
 	if iter.Error != nil && iter.Error != io.EOF {
-		iter.Error = fmt.Errorf("%v.%s", decoder.typ, iter.Error.Error())
+		iter.addStructErrorContext(decoder.typ)
 	}
 }
 
 type structFieldDecoder struct {
 	field        reflect2.StructField
 	fieldDecoder ValDecoder
+	name         string // field name in JSON, empty for the embedded struct holding the field
 }
 
 func (decoder *structFieldDecoder) Decode(ptr unsafe.Pointer, iter *Iterator) {
+	mark := iter.mark()
 	fieldPtr := decoder.field.UnsafeGet(ptr)
 	decoder.fieldDecoder.Decode(fieldPtr, iter)
-	if iter.Error != nil && iter.Error != io.EOF {
-		iter.Error = fmt.Errorf("%s: %s", decoder.field.Name(), iter.Error.Error())
+	if !iter.hasNewErrors(mark) {
+		return
+	}
+	if decoder.name == "" {
+		iter.recoverValue(mark, decoder.field.Type(), decoder.field.Name()+": ")
+	} else {
+		iter.recoverValue(mark, decoder.field.Type(), decoder.field.Name()+": ", decoder.name)
 	}
 }
 
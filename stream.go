@@ -1,6 +1,8 @@
 package jsoniter
 
 import (
+	"encoding/json"
+	"errors"
 	"io"
 )
 
@@ -74,6 +76,15 @@ func (stream *Stream) Write(p []byte) (nn int, err error) {
 	return len(p), nil
 }
 
+// addErrorContext prefixes the current error with the encoding context.
+// *json.MarshalerError is kept as is, to stay compatible with standard library.
+func (stream *Stream) addErrorContext(prefix string) {
+	if _, isMarshalerErr := stream.Error.(*json.MarshalerError); isMarshalerErr {
+		return
+	}
+	stream.Error = errors.New(prefix + stream.Error.Error())
+}
+
 // WriteByte writes a single byte.
 func (stream *Stream) writeByte(c byte) {
 	stream.buf = append(stream.buf, c)
@@ -1,6 +1,7 @@
 package jsoniter
 
 import (
+	"errors"
 	"unicode/utf8"
 )
 
@@ -217,6 +218,15 @@ var safeSet = [utf8.RuneSelf]bool{
 
 var hex = "0123456789abcdef"
 
+// writeInvalidUTF8 writes the replacement of invalid byte,
+// which is reported as error by InvalidUTF8Reject
+func (stream *Stream) writeInvalidUTF8() {
+	if stream.cfg.invalidUTF8 == InvalidUTF8Reject && stream.Error == nil {
+		stream.Error = errors.New("invalid UTF-8 in string")
+	}
+	stream.WriteRaw(`\ufffd`)
+}
+
 // WriteStringWithHTMLEscaped write string to stream with html special characters escaped
 func (stream *Stream) WriteStringWithHTMLEscaped(s string) {
 	valLen := len(s)
@@ -274,10 +284,14 @@ func writeStringSlowPathWithHTMLEscaped(stream *Stream, i int, s string, valLen
 		}
 		c, size := utf8.DecodeRuneInString(s[i:])
 		if c == utf8.RuneError && size == 1 {
+			if stream.cfg.invalidUTF8 == InvalidUTF8PassThrough {
+				i++
+				continue
+			}
 			if start < i {
 				stream.WriteRaw(s[start:i])
 			}
-			stream.WriteRaw(`\ufffd`)
+			stream.writeInvalidUTF8()
 			i++
 			start = i
 			continue
@@ -313,9 +327,10 @@ func (stream *Stream) WriteString(s string) {
 	stream.buf = append(stream.buf, '"')
 	// write string, the fast path, without utf8 and escape support
 	i := 0
+	validate := stream.cfg.validatesUTF8()
 	for ; i < valLen; i++ {
 		c := s[i]
-		if c > 31 && c != '"' && c != '\\' {
+		if c > 31 && c != '"' && c != '\\' && (c < utf8.RuneSelf || !validate) {
 			stream.buf = append(stream.buf, c)
 		} else {
 			break
@@ -362,6 +377,19 @@ func writeStringSlowPath(stream *Stream, i int, s string, valLen int) {
 			start = i
 			continue
 		}
+		if stream.cfg.validatesUTF8() {
+			if c, size := utf8.DecodeRuneInString(s[i:]); c != utf8.RuneError || size != 1 {
+				i += size
+				continue
+			}
+			if start < i {
+				stream.WriteRaw(s[start:i])
+			}
+			stream.writeInvalidUTF8()
+			i++
+			start = i
+			continue
+		}
 		i++
 		continue
 	}